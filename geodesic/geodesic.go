@@ -0,0 +1,172 @@
+// Package geodesic computes distances, lengths and areas on the
+// WGS84 ellipsoid for geographic coordinates.
+//
+// The planar metrics of package geom (such as Coordinate.distance)
+// treat coordinates as Cartesian, which is wrong for longitude/latitude data.
+// The functions of this package interpret a Coordinate as
+// (longitude, latitude) in degrees, with X holding the longitude and Y the latitude,
+// and return results in metres and square metres.
+package geodesic
+
+import (
+	"errors"
+	"math"
+)
+
+// Returned when the iterative solution of the inverse problem
+// does not converge, which happens for nearly antipodal points.
+// The method of Karney (2013), which handles these points, is not implemented.
+var ErrNotConverged = errors.New("geodesic: solution did not converge")
+
+const (
+	maxIterations = 200
+	// Convergence threshold of the iterations, in radians
+	convergence = 1e-12
+)
+
+// Represents an ellipsoid of revolution on which geodesic problems are solved.
+type Geodesic struct {
+	a float64
+	f float64
+	b float64
+}
+
+// The WGS84 ellipsoid.
+var WGS84 = NewGeodesic(6378137.0, 1/298.257223563)
+
+// Creates a Geodesic for the ellipsoid with the given
+// semi-major axis (in metres) and flattening.
+func NewGeodesic(a, f float64) Geodesic {
+	return Geodesic{
+		a: a,
+		f: f,
+		b: a * (1 - f),
+	}
+}
+
+// Solves the inverse geodesic problem using the method of Vincenty (1975):
+// given two points, computes the length of the geodesic between them
+// and the azimuths of the geodesic at each point.
+// Latitudes, longitudes and azimuths are in degrees; azimuths are measured
+// clockwise from north, and azi2 is the forward azimuth at the second point.
+//
+// Vincenty's iteration fails for nearly antipodal points, for which
+// ErrNotConverged is returned rather than a distance. This affects points
+// very close to each other's antipode, in particular points on or near
+// the equator which are more than about 179.4 degrees of longitude apart.
+func (g Geodesic) Inverse(lat1, lon1, lat2, lon2 float64) (s12, azi1, azi2 float64, err error) {
+	f := g.f
+	L := toRadians(lon2 - lon1)
+	U1 := math.Atan((1 - f) * math.Tan(toRadians(lat1)))
+	U2 := math.Atan((1 - f) * math.Tan(toRadians(lat2)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	var sinLambda, cosLambda, sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	converged := false
+	for i := 0; i < maxIterations; i++ {
+		sinLambda, cosLambda = math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// coincident points
+			return 0, 0, 0, nil
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		// on the equator the geodesic is a line of latitude
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		C := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		lambdaPrev := lambda
+		lambda = L + (1-C)*f*sinAlpha*
+			(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda) > math.Pi+math.Abs(L) {
+			break
+		}
+		if math.Abs(lambda-lambdaPrev) <= convergence {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return math.NaN(), math.NaN(), math.NaN(), ErrNotConverged
+	}
+	A, B := g.seriesCoefficients(cosSqAlpha)
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+	s12 = g.b * A * (sigma - deltaSigma)
+	azi1 = toDegrees(math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda))
+	azi2 = toDegrees(math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda))
+	return s12, azi1, azi2, nil
+}
+
+// Solves the direct geodesic problem using the method of Vincenty (1975):
+// given a starting point, an azimuth and a distance in metres,
+// computes the end point of the geodesic and the forward azimuth there.
+// Latitudes, longitudes and azimuths are in degrees; azimuths are measured
+// clockwise from north.
+func (g Geodesic) Direct(lat1, lon1, azi1, s12 float64) (lat2, lon2, azi2 float64) {
+	f := g.f
+	sinAlpha1, cosAlpha1 := math.Sincos(toRadians(azi1))
+	tanU1 := (1 - f) * math.Tan(toRadians(lat1))
+	cosU1 := 1 / math.Sqrt(1+tanU1*tanU1)
+	sinU1 := tanU1 * cosU1
+	sigma1 := math.Atan2(tanU1, cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cosSqAlpha := 1 - sinAlpha*sinAlpha
+	A, B := g.seriesCoefficients(cosSqAlpha)
+
+	sigma := s12 / (g.b * A)
+	var sinSigma, cosSigma, cos2SigmaM float64
+	for i := 0; i < maxIterations; i++ {
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sincos(sigma)
+		deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+		sigmaPrev := sigma
+		sigma = s12/(g.b*A) + deltaSigma
+		if math.Abs(sigma-sigmaPrev) <= convergence {
+			break
+		}
+	}
+	sinSigma, cosSigma = math.Sincos(sigma)
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+
+	x := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	lat2 = toDegrees(math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1-f)*math.Hypot(sinAlpha, x)))
+	lambda := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+	C := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+	L := lambda - (1-C)*f*sinAlpha*
+		(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+	lon2 = normalizeLongitude(lon1 + toDegrees(L))
+	azi2 = toDegrees(math.Atan2(sinAlpha, -x))
+	return lat2, lon2, azi2
+}
+
+// Computes the coefficients A and B of the series for the
+// length of the geodesic, given the square of the cosine of the
+// azimuth at the equator.
+func (g Geodesic) seriesCoefficients(cosSqAlpha float64) (A, B float64) {
+	uSq := cosSqAlpha * (g.a*g.a - g.b*g.b) / (g.b * g.b)
+	A = 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B = uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	return A, B
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// Normalizes a longitude to the range [-180, 180].
+func normalizeLongitude(lon float64) float64 {
+	return math.Remainder(lon, 360)
+}
@@ -0,0 +1,132 @@
+package geodesic
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+
+	"jts-core/geom"
+)
+
+// Flinders Peak and Buninyong, the test points of Vincenty (1975)
+var flindersPeak = geom.NewXYCoordinate(144+25/60.0+29.52440/3600, -(37 + 57/60.0 + 3.72030/3600))
+var buninyong = geom.NewXYCoordinate(143+55/60.0+35.38390/3600, -(37 + 39/60.0 + 10.15610/3600))
+
+func TestInverse(t *testing.T) {
+	assert := assert2.New(t)
+	s12, azi1, azi2, err := WGS84.Inverse(flindersPeak.GetY(), flindersPeak.GetX(), buninyong.GetY(), buninyong.GetX())
+	assert.Nil(err)
+	assert.InDelta(54972.271, s12, 1e-3)
+	assert.InDelta(306+52/60.0+5.37/3600-360, azi1, 1e-5)
+	assert.InDelta(127+10/60.0+25.07/3600-180, azi2, 1e-5)
+}
+
+func TestInverseCoincident(t *testing.T) {
+	s12, _, _, err := WGS84.Inverse(10, 20, 10, 20)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0.0, s12)
+}
+
+func TestInverseEquatorial(t *testing.T) {
+	s12, azi1, _, err := WGS84.Inverse(0, 0, 0, 1)
+	assert2.Nil(t, err)
+	// one degree of longitude along the equator
+	assert2.InDelta(t, 6378137*math.Pi/180, s12, 1e-6)
+	assert2.InDelta(t, 90, azi1, 1e-9)
+}
+
+func TestInverseNearlyAntipodal(t *testing.T) {
+	_, _, _, err := WGS84.Inverse(0, 0, 0.5, 179.7)
+	assert2.Equal(t, ErrNotConverged, err)
+}
+
+func TestDirect(t *testing.T) {
+	assert := assert2.New(t)
+	azi1 := 306 + 52/60.0 + 5.37/3600
+	lat2, lon2, azi2 := WGS84.Direct(flindersPeak.GetY(), flindersPeak.GetX(), azi1, 54972.271)
+	assert.InDelta(buninyong.GetY(), lat2, 1e-7)
+	assert.InDelta(buninyong.GetX(), lon2, 1e-7)
+	assert.InDelta(127+10/60.0+25.07/3600-180, azi2, 1e-5)
+}
+
+func TestDirectInverseRoundTrip(t *testing.T) {
+	for _, azi := range []float64{0, 30, 90, 135, -170} {
+		lat2, lon2, _ := WGS84.Direct(45, 170, azi, 2000000)
+		s12, azi1, _, err := WGS84.Inverse(45, 170, lat2, lon2)
+		assert2.Nil(t, err)
+		assert2.InDelta(t, 2000000, s12, 1e-5)
+		assert2.InDelta(t, azi, azi1, 1e-9)
+		assert2.True(t, lon2 >= -180 && lon2 <= 180)
+	}
+}
+
+func TestDistanceAndLength(t *testing.T) {
+	assert := assert2.New(t)
+	d, err := WGS84.Distance(flindersPeak, buninyong)
+	assert.Nil(err)
+	assert.InDelta(54972.271, d, 1e-3)
+
+	line := []geom.Coordinate{geom.NewXYCoordinate(0, 0), geom.NewXYCoordinate(1, 0), geom.NewXYCoordinate(2, 0)}
+	length, err := WGS84.Length(line)
+	assert.Nil(err)
+	assert.InDelta(2*6378137*math.Pi/180, length, 1e-6)
+
+	length, err = WGS84.Length(nil)
+	assert.Nil(err)
+	assert.Equal(0.0, length)
+}
+
+func TestArea(t *testing.T) {
+	assert := assert2.New(t)
+	ring := []geom.Coordinate{
+		geom.NewXYCoordinate(0, 0), geom.NewXYCoordinate(1, 0), geom.NewXYCoordinate(1, 1),
+		geom.NewXYCoordinate(0, 1), geom.NewXYCoordinate(0, 0),
+	}
+	// the area of the same ring with geodesic edges is 12308778361.47 m2
+	assert.InEpsilon(12308778361.47, WGS84.SignedArea(ring), 1e-5)
+	reversed := []geom.Coordinate{ring[4], ring[3], ring[2], ring[1], ring[0]}
+	assert.InEpsilon(-12308778361.47, WGS84.SignedArea(reversed), 1e-5)
+	assert.InEpsilon(12308778361.47, WGS84.Area(reversed), 1e-5)
+	assert.Equal(0.0, WGS84.Area(ring[:2]))
+}
+
+func TestAreaAcrossAntimeridian(t *testing.T) {
+	ring := []geom.Coordinate{
+		geom.NewXYCoordinate(179.5, 0), geom.NewXYCoordinate(-179.5, 0), geom.NewXYCoordinate(-179.5, 1),
+		geom.NewXYCoordinate(179.5, 1), geom.NewXYCoordinate(179.5, 0),
+	}
+	assert2.InEpsilon(t, 12308778361.47, WGS84.Area(ring), 1e-5)
+}
+
+func TestAreaAroundPole(t *testing.T) {
+	assert := assert2.New(t)
+	ring := []geom.Coordinate{
+		geom.NewXYCoordinate(0, 80), geom.NewXYCoordinate(90, 80), geom.NewXYCoordinate(180, 80),
+		geom.NewXYCoordinate(-90, 80), geom.NewXYCoordinate(0, 80),
+	}
+	// the ring is made of four quadrants meeting at the pole
+	quadrant := []geom.Coordinate{
+		geom.NewXYCoordinate(0, 80), geom.NewXYCoordinate(90, 80), geom.NewXYCoordinate(90, 90),
+		geom.NewXYCoordinate(0, 90), geom.NewXYCoordinate(0, 80),
+	}
+	quadrantArea := WGS84.SignedArea(quadrant)
+	assert.True(quadrantArea > 0)
+	// the area is less than that of the cap north of 80 degrees, about 3.9e12 m2
+	assert.True(4*quadrantArea < 3.9e12)
+	assert.InEpsilon(4*quadrantArea, WGS84.SignedArea(ring), 1e-9)
+	reversed := []geom.Coordinate{ring[4], ring[3], ring[2], ring[1], ring[0]}
+	assert.InEpsilon(-4*quadrantArea, WGS84.SignedArea(reversed), 1e-9)
+
+	// the mirrored ring around the south pole runs westwards to keep the pole on its left
+	south := []geom.Coordinate{
+		geom.NewXYCoordinate(0, -80), geom.NewXYCoordinate(-90, -80), geom.NewXYCoordinate(180, -80),
+		geom.NewXYCoordinate(90, -80), geom.NewXYCoordinate(0, -80),
+	}
+	assert.InEpsilon(4*quadrantArea, WGS84.SignedArea(south), 1e-9)
+}
+
+func TestHaversine(t *testing.T) {
+	d := Haversine(flindersPeak, buninyong)
+	assert2.InEpsilon(t, 54972.271, d, 5e-3)
+	assert2.InDelta(t, math.Pi*MeanEarthRadius, Haversine(geom.NewXYCoordinate(0, 0), geom.NewXYCoordinate(180, 0)), 1e-6)
+}
@@ -0,0 +1,124 @@
+package geodesic
+
+import (
+	"math"
+
+	"jts-core/geom"
+)
+
+// Mean radius of the Earth (the radius of the sphere with
+// the same mean of its three axes as WGS84), in metres.
+const MeanEarthRadius = 6371008.8
+
+// Computes the length in metres of the geodesic
+// between two (longitude, latitude) Coordinate(s).
+// The Z ordinate is ignored.
+// ErrNotConverged is returned for nearly antipodal points (see Inverse).
+func (g Geodesic) Distance(p1, p2 geom.Coordinate) (float64, error) {
+	s12, _, _, err := g.Inverse(p1.GetY(), p1.GetX(), p2.GetY(), p2.GetX())
+	return s12, err
+}
+
+// Computes the length in metres of a line of (longitude, latitude)
+// Coordinate(s) whose segments are geodesics.
+// ErrNotConverged is returned if a segment joins nearly antipodal points.
+func (g Geodesic) Length(pts []geom.Coordinate) (float64, error) {
+	result := 0.0
+	for i := 1; i < len(pts); i++ {
+		d, err := g.Distance(pts[i-1], pts[i])
+		if err != nil {
+			return math.NaN(), err
+		}
+		result += d
+	}
+	return result, nil
+}
+
+// Computes the area in square metres of a ring of (longitude, latitude)
+// Coordinate(s). The result is positive whatever the ring orientation.
+// See SignedArea for the method used.
+func (g Geodesic) Area(ring []geom.Coordinate) float64 {
+	return math.Abs(g.SignedArea(ring))
+}
+
+// Computes the signed area in square metres of a ring of
+// (longitude, latitude) Coordinate(s).
+// The area is positive if the ring is oriented counter-clockwise
+// and negative if it is oriented clockwise.
+// The ring is closed implicitly if its end points differ.
+//
+// This is not the area of a polygon with geodesic edges, but an approximation
+// of it: the ellipsoid is mapped onto the sphere of equal area using
+// authalic latitudes, and the edges are taken to be great circles on that sphere.
+// The area is computed there as the sum of the spherical excesses of the
+// trapezoids between each edge and the equator.
+// The authalic great circles are very close to geodesics for edges of moderate
+// length; for a 1 degree square at the equator the area differs from the geodesic
+// one by less than a part per million, but the difference grows with the length
+// of the edges.
+//
+// A ring which encircles a pole is taken to enclose that pole,
+// and as for other rings its area is positive if the pole lies to its left.
+// The signed area is reduced to at most half of the area of the ellipsoid.
+func (g Geodesic) SignedArea(ring []geom.Coordinate) float64 {
+	if len(ring) < 3 {
+		return 0
+	}
+	e := math.Sqrt(g.f * (2 - g.f))
+	qp := g.authalicQ(1, e)
+	radius := g.a * math.Sqrt(qp/2)
+
+	excess := 0.0
+	winding := 0.0
+	n := len(ring)
+	for i := 0; i < n; i++ {
+		p1 := ring[i]
+		p2 := ring[(i+1)%n]
+		dLon := toRadians(normalizeLongitude(p2.GetX() - p1.GetX()))
+		t1 := math.Tan(g.authalicLatitude(p1.GetY(), e, qp) / 2)
+		t2 := math.Tan(g.authalicLatitude(p2.GetY(), e, qp) / 2)
+		excess += 2 * math.Atan2(math.Tan(dLon/2)*(t1+t2), 1+t1*t2)
+		winding += dLon
+	}
+	// A ring encircling a pole winds once around the axis; the trapezoids then
+	// cover the zone between the ring and the equator rather than the ring interior,
+	// which is recovered by adding the area of a hemisphere (2 pi on the unit sphere).
+	area := 2*math.Pi*math.Round(winding/(2*math.Pi)) - excess
+	// keep the smaller of the two regions bounded by the ring
+	if area > 2*math.Pi {
+		area -= 4 * math.Pi
+	} else if area < -2*math.Pi {
+		area += 4 * math.Pi
+	}
+	return area * radius * radius
+}
+
+// Computes the authalic latitude, in radians, of a latitude in degrees.
+func (g Geodesic) authalicLatitude(lat, e, qp float64) float64 {
+	q := g.authalicQ(math.Sin(toRadians(lat)), e)
+	return math.Asin(math.Max(-1, math.Min(1, q/qp)))
+}
+
+// Computes the function q of the authalic latitude
+// for the sine of a latitude.
+func (g Geodesic) authalicQ(sinLat, e float64) float64 {
+	if e == 0 {
+		return 2 * sinLat
+	}
+	es := e * sinLat
+	return (1 - e*e) * (sinLat/(1-es*es) - math.Log((1-es)/(1+es))/(2*e))
+}
+
+// Computes the great-circle distance in metres between two
+// (longitude, latitude) Coordinate(s) on a sphere of radius
+// MeanEarthRadius, using the haversine formula.
+// This is faster than the ellipsoidal Distance, with a relative error
+// of up to about 0.5%.
+func Haversine(p1, p2 geom.Coordinate) float64 {
+	lat1 := toRadians(p1.GetY())
+	lat2 := toRadians(p2.GetY())
+	sinDLat := math.Sin((lat2 - lat1) / 2)
+	sinDLon := math.Sin(toRadians(p2.GetX()-p1.GetX()) / 2)
+	h := sinDLat*sinDLat + math.Cos(lat1)*math.Cos(lat2)*sinDLon*sinDLon
+	return 2 * MeanEarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,479 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+)
+
+// Represents an affine transformation on the 2D Cartesian plane.
+// It can be used to transform a Coordinate, an Envelope or
+// an array of Coordinate(s).
+//
+// An affine transformation is a mapping of the 2D plane into itself
+// via a series of transformations of the following basic types:
+//   - reflection (through a line)
+//   - rotation (around the origin)
+//   - scaling (relative to the origin)
+//   - shearing (in both the X and Y directions)
+//   - translation
+//
+// In general, affine transformations preserve straightness and parallel lines,
+// but do not preserve distance or shape.
+//
+// An affine transformation can be represented by a 3x3
+// matrix in the following form:
+//
+//	T = | m00 m01 m02 |
+//	    | m10 m11 m12 |
+//	    |  0   0   1  |
+//
+// A coordinate P = (x, y) can be transformed to a new coordinate P' = (x', y')
+// by representing it as a 3x1 matrix and using matrix multiplication to compute:
+//
+//	| x' |  = T x | x |
+//	| y' |        | y |
+//	| 1  |        | 1 |
+//
+// Affine transformations can be composed using the Compose method.
+// Composition is computed via multiplication of the
+// transformation matrices, and is defined as:
+//
+//	A.compose(B) = T_B x T_A
+//
+// This produces a transformation whose effect is that of A followed by B.
+// The methods Reflect, Rotate, Scale, Shear, and Translate
+// have the effect of composing a transformation of that type with
+// the transformation they are invoked on.
+//
+// The Z ordinate of transformed coordinates is left unchanged.
+type AffineTransformation struct {
+	m00 float64
+	m01 float64
+	m02 float64
+	m10 float64
+	m11 float64
+	m12 float64
+}
+
+// Constructs a new transformation whose
+// matrix has the specified values.
+func NewAffineTransformation(m00, m01, m02, m10, m11, m12 float64) AffineTransformation {
+	return AffineTransformation{
+		m00: m00,
+		m01: m01,
+		m02: m02,
+		m10: m10,
+		m11: m11,
+		m12: m12,
+	}
+}
+
+// Constructs a new identity transformation.
+func NewIdentityAffineTransformation() AffineTransformation {
+	result := AffineTransformation{}
+	result.setToIdentity()
+	return result
+}
+
+// Creates a transformation for a reflection about the
+// line (x0,y0) - (x1,y1).
+// An error is returned if the line points are equal.
+func NewReflectionTransformation(x0, y0, x1, y1 float64) (AffineTransformation, error) {
+	result := AffineTransformation{}
+	err := result.setToReflection(x0, y0, x1, y1)
+	return result, err
+}
+
+// Creates a transformation for a reflection about the
+// line (0,0) - (x,y).
+// An error is returned if the point is the origin.
+func NewReflectionTransformationThroughOrigin(x, y float64) (AffineTransformation, error) {
+	result := AffineTransformation{}
+	err := result.setToReflectionThroughOrigin(x, y)
+	return result, err
+}
+
+// Creates a transformation for a rotation
+// about the origin by an angle theta.
+// Positive angles correspond to a rotation
+// in the counter-clockwise direction.
+func NewRotationTransformation(theta float64) AffineTransformation {
+	result := AffineTransformation{}
+	result.setToRotation(math.Sin(theta), math.Cos(theta))
+	return result
+}
+
+// Creates a transformation for a rotation
+// about the point (x,y) by an angle theta.
+// Positive angles correspond to a rotation
+// in the counter-clockwise direction.
+func NewRotationTransformationAroundPoint(theta, x, y float64) AffineTransformation {
+	result := AffineTransformation{}
+	result.setToRotationAroundPoint(math.Sin(theta), math.Cos(theta), x, y)
+	return result
+}
+
+// Creates a transformation for a scaling relative to the origin.
+func NewScaleTransformation(xScale, yScale float64) AffineTransformation {
+	result := AffineTransformation{}
+	result.setToScale(xScale, yScale)
+	return result
+}
+
+// Creates a transformation for a scaling relative to the point (x,y).
+func NewScaleTransformationAroundPoint(xScale, yScale, x, y float64) AffineTransformation {
+	result := NewTranslationTransformation(-x, -y)
+	result.Scale(xScale, yScale)
+	result.Translate(x, y)
+	return result
+}
+
+// Creates a transformation for a shear.
+func NewShearTransformation(xShear, yShear float64) AffineTransformation {
+	result := AffineTransformation{}
+	result.setToShear(xShear, yShear)
+	return result
+}
+
+// Creates a transformation for a translation.
+func NewTranslationTransformation(x, y float64) AffineTransformation {
+	result := AffineTransformation{}
+	result.setToTranslation(x, y)
+	return result
+}
+
+// Sets this transformation to be the identity transformation.
+func (t *AffineTransformation) setToIdentity() {
+	t.m00 = 1.0
+	t.m01 = 0.0
+	t.m02 = 0.0
+	t.m10 = 0.0
+	t.m11 = 1.0
+	t.m12 = 0.0
+}
+
+// Sets this transformation to be a reflection about the
+// line defined by a line (x0,y0) - (x1,y1).
+func (t *AffineTransformation) setToReflection(x0, y0, x1, y1 float64) error {
+	if x0 == x1 && y0 == y1 {
+		return invalidArgumentError("Reflection line points must be distinct")
+	}
+	// translate line vector to origin
+	t.setToTranslation(-x0, -y0)
+
+	// rotate vector to positive x axis direction
+	dx := x1 - x0
+	dy := y1 - y0
+	d := math.Hypot(dx, dy)
+	sin := dy / d
+	cos := dx / d
+	t.rotateBySinCos(-sin, cos)
+	// reflect about the x axis
+	t.Scale(1, -1)
+	// rotate back
+	t.rotateBySinCos(sin, cos)
+	// translate back
+	t.Translate(x0, y0)
+	return nil
+}
+
+// Sets this transformation to be a reflection about the
+// line defined by vector (x,y).
+func (t *AffineTransformation) setToReflectionThroughOrigin(x, y float64) error {
+	if x == 0.0 && y == 0.0 {
+		return invalidArgumentError("Reflection vector must be non-zero")
+	}
+	// Handle special case - x = y.
+	// This case is specified explicitly to avoid roundoff error.
+	if x == y {
+		t.m00 = 0.0
+		t.m01 = 1.0
+		t.m02 = 0.0
+		t.m10 = 1.0
+		t.m11 = 0.0
+		t.m12 = 0.0
+		return nil
+	}
+	// rotate vector to positive x axis direction
+	d := math.Hypot(x, y)
+	sin := y / d
+	cos := x / d
+
+	// compute reflection matrix directly
+	cs2 := 2 * sin * cos
+	c2s2 := cos*cos - sin*sin
+	t.m00 = c2s2
+	t.m01 = cs2
+	t.m02 = 0.0
+	t.m10 = cs2
+	t.m11 = -c2s2
+	t.m12 = 0.0
+	return nil
+}
+
+// Sets this transformation to be a rotation around the origin
+// by specifying the sin and cos of the rotation angle directly.
+func (t *AffineTransformation) setToRotation(sinTheta, cosTheta float64) {
+	t.m00 = cosTheta
+	t.m01 = -sinTheta
+	t.m02 = 0.0
+	t.m10 = sinTheta
+	t.m11 = cosTheta
+	t.m12 = 0.0
+}
+
+// Sets this transformation to be a rotation around a given point (x,y)
+// by specifying the sin and cos of the rotation angle directly.
+func (t *AffineTransformation) setToRotationAroundPoint(sinTheta, cosTheta, x, y float64) {
+	t.m00 = cosTheta
+	t.m01 = -sinTheta
+	t.m02 = x - x*cosTheta + y*sinTheta
+	t.m10 = sinTheta
+	t.m11 = cosTheta
+	t.m12 = y - x*sinTheta - y*cosTheta
+}
+
+// Sets this transformation to be a scaling.
+func (t *AffineTransformation) setToScale(xScale, yScale float64) {
+	t.m00 = xScale
+	t.m01 = 0.0
+	t.m02 = 0.0
+	t.m10 = 0.0
+	t.m11 = yScale
+	t.m12 = 0.0
+}
+
+// Sets this transformation to be a shear.
+func (t *AffineTransformation) setToShear(xShear, yShear float64) {
+	t.m00 = 1.0
+	t.m01 = xShear
+	t.m02 = 0.0
+	t.m10 = yShear
+	t.m11 = 1.0
+	t.m12 = 0.0
+}
+
+// Sets this transformation to be a translation.
+func (t *AffineTransformation) setToTranslation(dx, dy float64) {
+	t.m00 = 1.0
+	t.m01 = 0.0
+	t.m02 = dx
+	t.m10 = 0.0
+	t.m11 = 1.0
+	t.m12 = dy
+}
+
+// Updates the value of this transformation
+// to that of a reflection transformation composed
+// with the current value.
+func (t *AffineTransformation) Reflect(x0, y0, x1, y1 float64) error {
+	trans, err := NewReflectionTransformation(x0, y0, x1, y1)
+	if err != nil {
+		return err
+	}
+	t.Compose(trans)
+	return nil
+}
+
+// Updates the value of this transformation
+// to that of a reflection transformation about the line (0,0) - (x,y)
+// composed with the current value.
+func (t *AffineTransformation) ReflectThroughOrigin(x, y float64) error {
+	trans, err := NewReflectionTransformationThroughOrigin(x, y)
+	if err != nil {
+		return err
+	}
+	t.Compose(trans)
+	return nil
+}
+
+// Updates the value of this transformation
+// to that of a rotation transformation composed
+// with the current value.
+// Positive angles correspond to a rotation
+// in the counter-clockwise direction.
+func (t *AffineTransformation) Rotate(theta float64) {
+	t.Compose(NewRotationTransformation(theta))
+}
+
+// Updates the value of this transformation
+// to that of a rotation around the point (x,y) composed
+// with the current value.
+// Positive angles correspond to a rotation
+// in the counter-clockwise direction.
+func (t *AffineTransformation) RotateAroundPoint(theta, x, y float64) {
+	t.Compose(NewRotationTransformationAroundPoint(theta, x, y))
+}
+
+func (t *AffineTransformation) rotateBySinCos(sinTheta, cosTheta float64) {
+	trans := AffineTransformation{}
+	trans.setToRotation(sinTheta, cosTheta)
+	t.Compose(trans)
+}
+
+// Updates the value of this transformation
+// to that of a scale transformation composed
+// with the current value.
+func (t *AffineTransformation) Scale(xScale, yScale float64) {
+	t.Compose(NewScaleTransformation(xScale, yScale))
+}
+
+// Updates the value of this transformation
+// to that of a shear transformation composed
+// with the current value.
+func (t *AffineTransformation) Shear(xShear, yShear float64) {
+	t.Compose(NewShearTransformation(xShear, yShear))
+}
+
+// Updates the value of this transformation
+// to that of a translation transformation composed
+// with the current value.
+func (t *AffineTransformation) Translate(x, y float64) {
+	t.Compose(NewTranslationTransformation(x, y))
+}
+
+// Updates this transformation to be
+// the composition of this transformation with the given AffineTransformation.
+// This produces a transformation whose effect
+// is equal to applying this transformation
+// followed by the argument transformation.
+// Mathematically,
+//
+//	A.compose(B) = T_B x T_A
+func (t *AffineTransformation) Compose(trans AffineTransformation) {
+	mp00 := trans.m00*t.m00 + trans.m01*t.m10
+	mp01 := trans.m00*t.m01 + trans.m01*t.m11
+	mp02 := trans.m00*t.m02 + trans.m01*t.m12 + trans.m02
+	mp10 := trans.m10*t.m00 + trans.m11*t.m10
+	mp11 := trans.m10*t.m01 + trans.m11*t.m11
+	mp12 := trans.m10*t.m02 + trans.m11*t.m12 + trans.m12
+	t.m00 = mp00
+	t.m01 = mp01
+	t.m02 = mp02
+	t.m10 = mp10
+	t.m11 = mp11
+	t.m12 = mp12
+}
+
+// Updates this transformation to be the composition
+// of a given AffineTransformation with this transformation.
+// This produces a transformation whose effect
+// is equal to applying the argument transformation
+// followed by this transformation.
+// Mathematically,
+//
+//	A.composeBefore(B) = T_A x T_B
+func (t *AffineTransformation) ComposeBefore(trans AffineTransformation) {
+	mp00 := t.m00*trans.m00 + t.m01*trans.m10
+	mp01 := t.m00*trans.m01 + t.m01*trans.m11
+	mp02 := t.m00*trans.m02 + t.m01*trans.m12 + t.m02
+	mp10 := t.m10*trans.m00 + t.m11*trans.m10
+	mp11 := t.m10*trans.m01 + t.m11*trans.m11
+	mp12 := t.m10*trans.m02 + t.m11*trans.m12 + t.m12
+	t.m00 = mp00
+	t.m01 = mp01
+	t.m02 = mp02
+	t.m10 = mp10
+	t.m11 = mp11
+	t.m12 = mp12
+}
+
+// Gets an array containing the entries
+// of the transformation matrix.
+// Only the 6 non-trivial entries are returned,
+// in the sequence: m00, m01, m02, m10, m11, m12
+func (t *AffineTransformation) GetMatrixEntries() []float64 {
+	return []float64{t.m00, t.m01, t.m02, t.m10, t.m11, t.m12}
+}
+
+// Computes the determinant of the transformation matrix.
+// The determinant is computed as:
+//
+//	| m00 m01 m02 |
+//	| m10 m11 m12 | = m00 * m11 - m01 * m10
+//	|  0   0   1  |
+//
+// If the determinant is zero,
+// the transform is singular (not invertible),
+// and operations which attempt to compute
+// an inverse will return an error.
+func (t *AffineTransformation) GetDeterminant() float64 {
+	return t.m00*t.m11 - t.m01*t.m10
+}
+
+// Computes the inverse of this transformation, if one exists.
+// The inverse is the transformation which when
+// composed with this one produces the identity transformation.
+// A transformation has an inverse if and only if it
+// is not singular (i.e. its determinant is non-zero).
+// Geometrically, an transformation is non-invertible
+// if it maps the plane to a line or a point.
+// If no inverse exists an error is returned.
+func (t *AffineTransformation) GetInverse() (AffineTransformation, error) {
+	det := t.GetDeterminant()
+	if det == 0 {
+		return AffineTransformation{}, ErrNonInvertible
+	}
+	return AffineTransformation{
+		m00: t.m11 / det,
+		m01: -t.m01 / det,
+		m02: (t.m01*t.m12 - t.m02*t.m11) / det,
+		m10: -t.m10 / det,
+		m11: t.m00 / det,
+		m12: (-t.m00*t.m12 + t.m10*t.m02) / det,
+	}, nil
+}
+
+// Tests if this transformation is the identity transformation.
+func (t *AffineTransformation) IsIdentity() bool {
+	return t.m00 == 1 && t.m01 == 0 && t.m02 == 0 &&
+		t.m10 == 0 && t.m11 == 1 && t.m12 == 0
+}
+
+// Applies this transformation to the given Coordinate
+// and returns the transformed Coordinate.
+// The Z ordinate is copied unchanged.
+func (t *AffineTransformation) Transform(src Coordinate) Coordinate {
+	result := src.clone()
+	result.x = t.m00*src.x + t.m01*src.y + t.m02
+	result.y = t.m10*src.x + t.m11*src.y + t.m12
+	return result
+}
+
+// Applies this transformation to all the Coordinate(s)
+// of the array, in place.
+func (t *AffineTransformation) TransformCoordinates(pts []Coordinate) {
+	for i := range pts {
+		pts[i] = t.Transform(pts[i])
+	}
+}
+
+// Computes the Envelope of the transformed region of an Envelope.
+// Since the transformed rectangle may be rotated or sheared,
+// the result is the bounding box of its four transformed corners.
+// A null Envelope is returned unchanged.
+func (t *AffineTransformation) TransformEnvelope(env Envelope) Envelope {
+	if env.isNull() {
+		return NewEmptyEnvelope()
+	}
+	result := NewEmptyEnvelope()
+	for _, corner := range []Coordinate{
+		NewXYCoordinate(env.minX, env.minY),
+		NewXYCoordinate(env.minX, env.maxY),
+		NewXYCoordinate(env.maxX, env.minY),
+		NewXYCoordinate(env.maxX, env.maxY),
+	} {
+		result.expandToIncludeCoordinate(t.Transform(corner))
+	}
+	return result
+}
+
+// Tests if another transformation is equal to this one.
+func (t *AffineTransformation) Equals(other AffineTransformation) bool {
+	return *t == other
+}
+
+func (t AffineTransformation) String() string {
+	return fmt.Sprintf("AffineTransformation[[%g, %g, %g], [%g, %g, %g]]",
+		t.m00, t.m01, t.m02, t.m10, t.m11, t.m12)
+}
@@ -0,0 +1,242 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestRotationByRightAngleAboutOrigin(t *testing.T) {
+	trans := NewRotationTransformation(math.Pi / 2)
+	checkTransformation(10, 0, trans, 0, 10, t)
+	checkTransformation(0, 10, trans, -10, 0, t)
+	checkTransformation(-10, -10, trans, 10, -10, t)
+}
+
+func TestRotationByAngleOfThreeFourFiveTriangle(t *testing.T) {
+	trans := NewRotationTransformation(math.Atan(4.0 / 3.0))
+	checkTransformation(10, 0, trans, 6, 8, t)
+}
+
+func TestRotationAroundPointKeepsPointFixed(t *testing.T) {
+	trans := NewRotationTransformationAroundPoint(math.Pi/2, 1, 1)
+	checkTransformation(1, 1, trans, 1, 1, t)
+	checkTransformation(10, 0, trans, 2, 10, t)
+	checkTransformation(0, 10, trans, -8, 0, t)
+	checkTransformation(-10, -10, trans, 12, -10, t)
+}
+
+func TestScaleMultipliesEachOrdinate(t *testing.T) {
+	trans := NewScaleTransformation(2, 3)
+	checkTransformation(10, 0, trans, 20, 0, t)
+	checkTransformation(0, 10, trans, 0, 30, t)
+	checkTransformation(-10, -10, trans, -20, -30, t)
+}
+
+func TestScaleAroundPointKeepsPointFixed(t *testing.T) {
+	trans := NewScaleTransformationAroundPoint(2, 3, 1, 1)
+	checkTransformation(10, 0, trans, 19, -2, t)
+	checkTransformation(1, 1, trans, 1, 1, t)
+}
+
+func TestShearAddsScaledOtherOrdinate(t *testing.T) {
+	trans := NewShearTransformation(2, 3)
+	checkTransformation(10, 0, trans, 10, 30, t)
+}
+
+func TestTranslationOffsetsEachPoint(t *testing.T) {
+	trans := NewTranslationTransformation(2, 3)
+	checkTransformation(1, 0, trans, 3, 3, t)
+	checkTransformation(0, 0, trans, 2, 3, t)
+	checkTransformation(-10, -5, trans, -8, -2, t)
+}
+
+func TestReflectionThroughDiagonalSwapsOrdinates(t *testing.T) {
+	trans, err := NewReflectionTransformationThroughOrigin(1, 1)
+	assert2.Nil(t, err)
+	checkTransformation(10, 0, trans, 0, 10, t)
+	checkTransformation(0, 10, trans, 10, 0, t)
+	checkTransformation(-10, -10, trans, -10, -10, t)
+	checkTransformation(-3, -4, trans, -4, -3, t)
+}
+
+func TestReflectionThroughAntiDiagonalSwapsAndNegatesOrdinates(t *testing.T) {
+	trans, err := NewReflectionTransformationThroughOrigin(1, -1)
+	assert2.Nil(t, err)
+	checkTransformation(10, 0, trans, 0, -10, t)
+	checkTransformation(0, 10, trans, -10, 0, t)
+	checkTransformation(-10, -10, trans, 10, 10, t)
+	checkTransformation(-3, -4, trans, 4, 3, t)
+}
+
+func TestReflectionAboutLineNotThroughOrigin(t *testing.T) {
+	trans, err := NewReflectionTransformation(0, 5, 5, 0)
+	assert2.Nil(t, err)
+	checkTransformation(5, 0, trans, 5, 0, t)
+	checkTransformation(0, 0, trans, 5, 5, t)
+	checkTransformation(-10, -10, trans, 15, 15, t)
+}
+
+func TestReflectionAboutDegenerateLineFails(t *testing.T) {
+	_, err := NewReflectionTransformation(1, 1, 1, 1)
+	assert2.NotNil(t, err)
+	_, err = NewReflectionTransformationThroughOrigin(0, 0)
+	assert2.NotNil(t, err)
+	trans := NewIdentityAffineTransformation()
+	assert2.NotNil(t, trans.Reflect(2, 2, 2, 2))
+	assert2.True(t, trans.IsIdentity())
+}
+
+func TestComposeAppliesOtherTransformationAfter(t *testing.T) {
+	checkTransformationFromComposite(NewRotationTransformation(math.Pi/2), NewRotationTransformation(math.Pi/2), t)
+	checkTransformationFromComposite(NewScaleTransformation(2, 3), NewTranslationTransformation(5, -7), t)
+	checkTransformationFromComposite(NewShearTransformation(0.5, 1), NewRotationTransformationAroundPoint(1, 3, 4), t)
+}
+
+func TestComposeBeforeAppliesOtherTransformationFirst(t *testing.T) {
+	first := NewTranslationTransformation(1, 0)
+	second := NewScaleTransformation(2, 2)
+	trans := NewIdentityAffineTransformation()
+	trans.Compose(second)
+	trans.ComposeBefore(first)
+	checkTransformation(1, 1, trans, 4, 2, t)
+}
+
+func TestInverseComposesToIdentity(t *testing.T) {
+	checkInverse(NewRotationTransformationAroundPoint(1, 10, 20), t)
+	checkInverse(NewScaleTransformation(2, 3), t)
+	checkInverse(NewShearTransformation(2, 3), t)
+	trans := NewTranslationTransformation(3, 4)
+	trans.Rotate(1)
+	checkInverse(trans, t)
+}
+
+func TestSingularTransformationHasNoInverse(t *testing.T) {
+	trans := NewScaleTransformation(0, 1)
+	_, err := trans.GetInverse()
+	assert2.NotNil(t, err)
+}
+
+func TestTransformPreservesZ(t *testing.T) {
+	trans := NewTranslationTransformation(2, 3)
+	p := trans.Transform(NewCoordinate(1, 1, 7))
+	assert2.Equal(t, 7.0, p.z)
+}
+
+func TestTransformCoordinatesInPlace(t *testing.T) {
+	trans := NewTranslationTransformation(10, 0)
+	pts := createLine(0, 0, 1, 1)
+	trans.TransformCoordinates(pts)
+	checkLine(createLine(10, 0, 11, 1), pts, t)
+}
+
+func TestTransformEnvelopeCoversTransformedCorners(t *testing.T) {
+	assert := assert2.New(t)
+	trans := NewTranslationTransformation(1, 2)
+	assert.Equal(NewEnvelope(1, 11, 2, 22), trans.TransformEnvelope(NewEnvelope(0, 10, 0, 20)))
+	rot := NewRotationTransformation(math.Pi / 4)
+	env := rot.TransformEnvelope(NewEnvelope(0, 1, 0, 1))
+	assert.InDelta(-math.Sqrt2/2, env.minX, 1e-12)
+	assert.InDelta(math.Sqrt2/2, env.maxX, 1e-12)
+	assert.InDelta(0, env.minY, 1e-12)
+	assert.InDelta(math.Sqrt2, env.maxY, 1e-12)
+	empty := trans.TransformEnvelope(NewEmptyEnvelope())
+	assert.True(empty.isNull())
+}
+
+func TestEqualsAndIdentity(t *testing.T) {
+	assert := assert2.New(t)
+	trans := NewIdentityAffineTransformation()
+	assert.True(trans.IsIdentity())
+	trans.Translate(1, 1)
+	assert.False(trans.IsIdentity())
+	assert.True(trans.Equals(NewTranslationTransformation(1, 1)))
+	assert.Equal("AffineTransformation[[1, 0, 1], [0, 1, 1]]", trans.String())
+}
+
+func TestOneControlVectorGivesTranslation(t *testing.T) {
+	trans, err := NewAffineTransformationFromControlVectors(
+		createLine(1, 1), createLine(4, 5))
+	assert2.Nil(t, err)
+	checkTransformation(0, 0, trans, 3, 4, t)
+}
+
+func TestTwoControlVectorsGiveSimilarity(t *testing.T) {
+	trans, err := NewAffineTransformationFromControlVectors(
+		createLine(0, 0, 1, 0), createLine(10, 10, 10, 12))
+	assert2.Nil(t, err)
+	checkTransformation(0, 0, trans, 10, 10, t)
+	checkTransformation(1, 0, trans, 10, 12, t)
+	checkTransformation(0, 1, trans, 8, 10, t)
+}
+
+func TestThreeOrMoreControlVectorsFitAffineTransformation(t *testing.T) {
+	expected := NewShearTransformation(0.5, 0.25)
+	expected.Rotate(0.3)
+	expected.Scale(2, 3)
+	expected.Translate(100, 200)
+	src := createLine(0, 0, 10, 0, 0, 10, 7, 3, -4, 9)
+	dest := copyDeepCoordinateArray(src)
+	expected.TransformCoordinates(dest)
+
+	trans, err := NewAffineTransformationFromControlVectors(src[:3], dest[:3])
+	assert2.Nil(t, err)
+	checkMatrixEqual(expected, trans, t)
+
+	trans, err = NewAffineTransformationFromControlVectors(src, dest)
+	assert2.Nil(t, err)
+	checkMatrixEqual(expected, trans, t)
+}
+
+func TestDegenerateControlVectorsFail(t *testing.T) {
+	_, err := NewAffineTransformationFromControlVectors(nil, nil)
+	assert2.NotNil(t, err)
+	_, err = NewAffineTransformationFromControlVectors(createLine(0, 0), createLine(0, 0, 1, 1))
+	assert2.NotNil(t, err)
+	_, err = NewAffineTransformationFromControlVectors(createLine(0, 0, 0, 0), createLine(0, 0, 1, 1))
+	assert2.NotNil(t, err)
+	_, err = NewAffineTransformationFromControlVectors(
+		createLine(0, 0, 1, 1, 2, 2), createLine(0, 0, 1, 0, 0, 1))
+	assert2.NotNil(t, err)
+}
+
+func checkTransformation(x, y float64, trans AffineTransformation, xp, yp float64, t *testing.T) {
+	p := trans.Transform(NewXYCoordinate(x, y))
+	assert2.InDelta(t, xp, p.x, 1e-10, "unexpected x-ordinate for (%g, %g)", x, y)
+	assert2.InDelta(t, yp, p.y, 1e-10, "unexpected y-ordinate for (%g, %g)", x, y)
+	// the inverse, if it exists, must map the result back
+	inverse, err := trans.GetInverse()
+	if err != nil {
+		return
+	}
+	q := inverse.Transform(p)
+	assert2.InDelta(t, x, q.x, 1e-10)
+	assert2.InDelta(t, y, q.y, 1e-10)
+}
+
+func checkTransformationFromComposite(first, second AffineTransformation, t *testing.T) {
+	composite := first
+	composite.Compose(second)
+	for _, p := range createLine(0, 0, 10, 0, -3, 7, 123, -45) {
+		p1 := first.Transform(p)
+		expected := second.Transform(p1)
+		actual := composite.Transform(p)
+		assert2.True(t, expected.equals2DWithTolerance(&actual, 1e-10), "expected %v, got %v", expected, actual)
+	}
+}
+
+func checkInverse(trans AffineTransformation, t *testing.T) {
+	inverse, err := trans.GetInverse()
+	assert2.Nil(t, err)
+	composite := trans
+	composite.Compose(inverse)
+	checkMatrixEqual(NewIdentityAffineTransformation(), composite, t)
+}
+
+func checkMatrixEqual(expected, actual AffineTransformation, t *testing.T) {
+	e := expected.GetMatrixEntries()
+	a := actual.GetMatrixEntries()
+	for i := range e {
+		assert2.InDelta(t, e[i], a[i], 1e-9, "unexpected matrix entry %d: %v", i, actual)
+	}
+}
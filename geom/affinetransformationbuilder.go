@@ -0,0 +1,100 @@
+package geom
+
+import "math"
+
+// Creates an AffineTransformation defined by a set of control vectors.
+// A control vector consists of a source point and a destination point,
+// which is the image of the source point under the desired transformation.
+// The number of control vectors determines the kind of transformation:
+//   - 1 vector: a translation
+//   - 2 vectors: a similarity transformation
+//     (a combination of translation, rotation and uniform scaling)
+//   - 3 vectors: the affine transformation which maps the source
+//     points exactly onto the destination points
+//   - more than 3 vectors: the affine transformation which best fits
+//     the control vectors in the least-squares sense
+//
+// An error is returned if the arrays have different lengths, are empty,
+// or if the source points do not determine a unique transformation
+// (e.g. they are coincident or collinear).
+func NewAffineTransformationFromControlVectors(src, dest []Coordinate) (AffineTransformation, error) {
+	if len(src) != len(dest) {
+		return AffineTransformation{}, invalidArgumentError("Source and destination arrays must have the same length")
+	}
+	switch len(src) {
+	case 0:
+		return AffineTransformation{}, invalidArgumentError("At least one control vector is required")
+	case 1:
+		return NewTranslationTransformation(dest[0].x-src[0].x, dest[0].y-src[0].y), nil
+	case 2:
+		return NewAffineTransformationFromBaseLines(src[0], src[1], dest[0], dest[1])
+	}
+	return fitAffineTransformation(src, dest)
+}
+
+// Creates an AffineTransformation defined by a pair of control vectors,
+// given as a source base line and a destination base line.
+// The result is the similarity transformation (translation, rotation and
+// uniform scaling) which maps the source line onto the destination line.
+// An error is returned if the source base line has zero length.
+func NewAffineTransformationFromBaseLines(src0, src1, dest0, dest1 Coordinate) (AffineTransformation, error) {
+	srcDist := src1.distance(&src0)
+	if srcDist == 0.0 {
+		return AffineTransformation{}, invalidArgumentError("Source base line must have non-zero length")
+	}
+	destDist := dest1.distance(&dest0)
+	angle := math.Atan2(dest1.y-dest0.y, dest1.x-dest0.x) - math.Atan2(src1.y-src0.y, src1.x-src0.x)
+	scale := destDist / srcDist
+
+	trans := NewTranslationTransformation(-src0.x, -src0.y)
+	trans.Rotate(angle)
+	trans.Scale(scale, scale)
+	trans.Translate(dest0.x, dest0.y)
+	return trans, nil
+}
+
+// Computes the affine transformation which maps the source points onto
+// the destination points with the least sum of squared errors.
+// The points are centred on their means before solving the normal equations,
+// to reduce round-off for coordinates far from the origin.
+func fitAffineTransformation(src, dest []Coordinate) (AffineTransformation, error) {
+	n := float64(len(src))
+	var meanX, meanY, meanU, meanV float64
+	for i := range src {
+		meanX += src[i].x
+		meanY += src[i].y
+		meanU += dest[i].x
+		meanV += dest[i].y
+	}
+	meanX /= n
+	meanY /= n
+	meanU /= n
+	meanV /= n
+
+	var sxx, sxy, syy, sxu, syu, sxv, syv float64
+	for i := range src {
+		x := src[i].x - meanX
+		y := src[i].y - meanY
+		u := dest[i].x - meanU
+		v := dest[i].y - meanV
+		sxx += x * x
+		sxy += x * y
+		syy += y * y
+		sxu += x * u
+		syu += y * u
+		sxv += x * v
+		syv += y * v
+	}
+	det := sxx*syy - sxy*sxy
+	if det <= 1e-12*sxx*syy || det == 0 {
+		return AffineTransformation{}, invalidArgumentError("Control points must not be collinear")
+	}
+	m00 := (sxu*syy - syu*sxy) / det
+	m01 := (syu*sxx - sxu*sxy) / det
+	m10 := (sxv*syy - syv*sxy) / det
+	m11 := (syv*sxx - sxv*sxy) / det
+	return NewAffineTransformation(
+		m00, m01, meanU-m00*meanX-m01*meanY,
+		m10, m11, meanV-m10*meanX-m11*meanY,
+	), nil
+}
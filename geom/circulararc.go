@@ -0,0 +1,136 @@
+package geom
+
+import "math"
+
+// The maximum number of segments in the linearization of an arc.
+const maxArcSegments = 100000
+
+// A circular arc defined by three points:
+// the start point, a point on the arc, and the end point.
+// If the start and end points are equal, the arc is a full circle
+// whose diameter is the segment from the start point to the middle point.
+// If the three points are collinear, the arc degenerates to
+// the straight segments p0-p1-p2.
+type circularArc struct {
+	p0 Coordinate
+	p1 Coordinate
+	p2 Coordinate
+}
+
+// Tests whether the arc is a full circle.
+func (a *circularArc) isFullCircle() bool {
+	return a.p0.equals2D(&a.p2) && !a.p0.equals2D(&a.p1)
+}
+
+// Tests whether the arc degenerates to straight segments.
+func (a *circularArc) isLinear() bool {
+	if a.isFullCircle() {
+		return false
+	}
+	return orientationIndex(a.p0, a.p1, a.p2) == 0
+}
+
+// Computes the centre of the circle containing the arc.
+func (a *circularArc) centre() Coordinate {
+	if a.isFullCircle() {
+		seg := NewLineSegment(a.p0, a.p1)
+		return seg.midPoint()
+	}
+	return circumcentre(a.p0, a.p1, a.p2)
+}
+
+// Computes the angle of the start point and the signed angle swept by the arc,
+// which is positive for counter-clockwise arcs.
+// Full circles are swept counter-clockwise.
+func (a *circularArc) angles(c Coordinate) (float64, float64) {
+	start := math.Atan2(a.p0.y-c.y, a.p0.x-c.x)
+	if a.isFullCircle() {
+		return start, 2 * math.Pi
+	}
+	sweep := math.Atan2(a.p2.y-c.y, a.p2.x-c.x) - start
+	if orientationIndex(a.p0, a.p1, a.p2) > 0 {
+		for sweep <= 0 {
+			sweep += 2 * math.Pi
+		}
+	} else {
+		for sweep >= 0 {
+			sweep -= 2 * math.Pi
+		}
+	}
+	return start, sweep
+}
+
+// Tests whether the direction with a given angle from the centre
+// lies within the sweep of the arc.
+func containsAngle(start, sweep, angle float64) bool {
+	d := angle - start
+	if sweep < 0 {
+		d = -d
+	}
+	d = math.Mod(d, 2*math.Pi)
+	if d < 0 {
+		d += 2 * math.Pi
+	}
+	return d <= math.Abs(sweep)
+}
+
+// Computes the exact envelope of the arc, which includes the
+// extreme points of the circle which lie on the arc.
+func (a *circularArc) envelope() Envelope {
+	env := NewPointEnvelope(a.p0)
+	env.expandToIncludeCoordinate(a.p1)
+	env.expandToIncludeCoordinate(a.p2)
+	if a.isLinear() {
+		return env
+	}
+	c := a.centre()
+	r := c.distance(&a.p0)
+	start, sweep := a.angles(c)
+	if containsAngle(start, sweep, 0) {
+		env.expandToInclude(c.x+r, c.y)
+	}
+	if containsAngle(start, sweep, math.Pi/2) {
+		env.expandToInclude(c.x, c.y+r)
+	}
+	if containsAngle(start, sweep, math.Pi) {
+		env.expandToInclude(c.x-r, c.y)
+	}
+	if containsAngle(start, sweep, -math.Pi/2) {
+		env.expandToInclude(c.x, c.y-r)
+	}
+	return env
+}
+
+// Computes the vertices of a line approximating the arc,
+// such that the distance between the line and the arc
+// is no greater than the tolerance.
+// The end points of the arc are vertices of the line.
+// Returns ErrInvalidArgument if the tolerance is so small relative to
+// the radius that more than maxArcSegments segments would be needed.
+func (a *circularArc) linearize(tolerance float64) ([]Coordinate, error) {
+	if a.isLinear() {
+		return removeRepeatedPoints([]Coordinate{a.p0, a.p1, a.p2}), nil
+	}
+	c := a.centre()
+	r := c.distance(&a.p0)
+	// the largest angle subtended by a chord whose sagitta is within the tolerance,
+	// limited to a quarter circle so that the shape of the arc is kept
+	maxAngle := math.Pi / 2
+	if tolerance < r {
+		maxAngle = math.Min(maxAngle, 2*math.Acos(1-tolerance/r))
+	}
+	start, sweep := a.angles(c)
+	// compared as a float, since the count is infinite when the angle is 0
+	segments := math.Ceil(math.Abs(sweep) / maxAngle)
+	if !(segments <= maxArcSegments) {
+		return nil, invalidArgumentError("Linearization tolerance %g is too small for an arc of radius %g", tolerance, r)
+	}
+	n := int(segments)
+	pts := make([]Coordinate, 0, n+1)
+	pts = append(pts, a.p0)
+	for i := 1; i < n; i++ {
+		angle := start + sweep*float64(i)/float64(n)
+		pts = append(pts, NewXYCoordinate(c.x+r*math.Cos(angle), c.y+r*math.Sin(angle)))
+	}
+	return append(pts, a.p2), nil
+}
@@ -0,0 +1,121 @@
+package geom
+
+// Constructs a concave hull of a set of points.
+// A concave hull is a possibly non-convex polygon containing all the input points.
+// A given set of points has a sequence of hulls of increasing concaveness,
+// determined by a numeric target parameter.
+//
+// The hull is constructed by removing the longest outer edges
+// of the Delaunay Triangulation of the points,
+// until the target criterion parameter is reached.
+//
+// The target criteria are:
+//   - Maximum Edge Length: the length of the longest edge between hull vertices
+//     is no larger than this value.
+//   - Maximum Edge Length Ratio: determines the Maximum Edge Length
+//     as a fraction of the difference between the longest and shortest
+//     edge lengths in the Delaunay Triangulation.
+//     This normalizes the Maximum Edge Length to be scale-free.
+//     A value of 1 produces the convex hull; a value of 0 produces the
+//     maximum concaveness.
+//
+// The hull is a single polygon which contains all the points
+// (it never splits into several parts), and optionally may contain holes.
+type ConcaveHull struct {
+	inputPts           []Coordinate
+	maxEdgeLength      float64
+	maxEdgeLengthRatio float64
+	isHolesAllowed     bool
+}
+
+// Creates a new instance for a given set of points,
+// with a maximum edge length of 0 (the most concave hull).
+func NewConcaveHull(pts []Coordinate) ConcaveHull {
+	return ConcaveHull{
+		inputPts:           copyDeepCoordinateArray(pts),
+		maxEdgeLengthRatio: -1,
+	}
+}
+
+// Computes the concave hull of the points using
+// a given maximum edge length.
+// Returns the shell and holes of the hull.
+func ConcaveHullByLength(pts []Coordinate, maxLength float64) ([]Coordinate, [][]Coordinate, error) {
+	hull := NewConcaveHull(pts)
+	if err := hull.SetMaximumEdgeLength(maxLength); err != nil {
+		return nil, nil, err
+	}
+	return hull.GetHull()
+}
+
+// Computes the concave hull of the points using
+// a given maximum edge length ratio.
+// Returns the shell and holes of the hull.
+func ConcaveHullByLengthRatio(pts []Coordinate, lengthRatio float64) ([]Coordinate, [][]Coordinate, error) {
+	hull := NewConcaveHull(pts)
+	if err := hull.SetMaximumEdgeLengthRatio(lengthRatio); err != nil {
+		return nil, nil, err
+	}
+	return hull.GetHull()
+}
+
+// Sets the target maximum edge length for the concave hull.
+// The length value must be zero or greater.
+//   - The value 0.0 produces the concave hull of smallest area
+//     that is still connected.
+//   - Larger values produce less concave results.
+//     A value equal or greater than the longest Delaunay Triangulation edge length
+//     produces the convex hull.
+func (h *ConcaveHull) SetMaximumEdgeLength(edgeLength float64) error {
+	if !(edgeLength >= 0) {
+		return invalidArgumentError("Edge length must be non-negative")
+	}
+	h.maxEdgeLength = edgeLength
+	h.maxEdgeLengthRatio = -1
+	return nil
+}
+
+// Sets the target maximum edge length ratio for the concave hull.
+// The edge length ratio is a fraction of the difference
+// between the longest and shortest edge lengths
+// in the Delaunay Triangulation of the input points.
+// It is a value in the range 0 to 1.
+//   - The value 0.0 produces a concave hull of minimum area
+//     that is still connected.
+//   - The value 1.0 produces the convex hull.
+func (h *ConcaveHull) SetMaximumEdgeLengthRatio(edgeLengthRatio float64) error {
+	if !(edgeLengthRatio >= 0 && edgeLengthRatio <= 1) {
+		return invalidArgumentError("Edge length ratio must be in range [0,1]")
+	}
+	h.maxEdgeLengthRatio = edgeLengthRatio
+	return nil
+}
+
+// Sets whether holes are allowed in the concave hull polygon.
+func (h *ConcaveHull) SetHolesAllowed(isHolesAllowed bool) {
+	h.isHolesAllowed = isHolesAllowed
+}
+
+// Gets the computed concave hull, as a shell in counter-clockwise order
+// and a list of holes in clockwise order.
+// If the points do not form a triangle (e.g. they are collinear)
+// the shell is empty.
+func (h *ConcaveHull) GetHull() ([]Coordinate, [][]Coordinate, error) {
+	builder := NewDelaunayTriangulationBuilder()
+	builder.SetSites(h.inputPts)
+	triangles, err := builder.GetTriangles()
+	if err != nil {
+		return nil, nil, err
+	}
+	hullTris := newHullTriangulation(triangles)
+	maxEdgeLength := h.maxEdgeLength
+	if h.maxEdgeLengthRatio >= 0 {
+		maxEdgeLength = hullTris.computeTargetEdgeLength(h.maxEdgeLengthRatio)
+	}
+	hullTris.erodeBorder(maxEdgeLength)
+	if h.isHolesAllowed {
+		hullTris.removeHoles(maxEdgeLength)
+	}
+	shell, holes := hullTris.toRings()
+	return shell, holes, nil
+}
@@ -0,0 +1,114 @@
+package geom
+
+import (
+	"errors"
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+// Creates the points of a grid of the given size, omitting those
+// for which the skip function is true.
+func createGridPoints(size int, skip func(i, j int) bool) []Coordinate {
+	var pts []Coordinate
+	for i := 0; i <= size; i++ {
+		for j := 0; j <= size; j++ {
+			if skip == nil || !skip(i, j) {
+				pts = append(pts, NewXYCoordinate(float64(i), float64(j)))
+			}
+		}
+	}
+	return pts
+}
+
+func createUPoints() []Coordinate {
+	return createGridPoints(6, func(i, j int) bool {
+		return i >= 2 && i <= 4 && j >= 2
+	})
+}
+
+func TestConcaveHullByLength(t *testing.T) {
+	shell, holes, err := ConcaveHullByLength(createUPoints(), 1.5)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, len(holes))
+	assert2.True(t, isRing(shell))
+	assert2.Equal(t, 17.0, signedRingArea(shell))
+	assert2.True(t, shell[0].equals2D(&Coordinate{x: 0, y: 0}))
+	// the notch is open down to the lower band
+	assert2.True(t, indexOf(NewXYCoordinate(3, 1), shell) > 0)
+}
+
+func TestConcaveHullByLengthRatioConvex(t *testing.T) {
+	shell, holes, err := ConcaveHullByLengthRatio(createUPoints(), 1)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, len(holes))
+	assert2.Equal(t, 36.0, signedRingArea(shell))
+}
+
+func TestConcaveHullHoles(t *testing.T) {
+	pts := createGridPoints(6, func(i, j int) bool {
+		return i >= 2 && i <= 4 && j >= 2 && j <= 4
+	})
+	hull := NewConcaveHull(pts)
+	assert2.Nil(t, hull.SetMaximumEdgeLength(1.5))
+	shell, holes, err := hull.GetHull()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, len(holes))
+	assert2.Equal(t, 36.0, signedRingArea(shell))
+
+	hull.SetHolesAllowed(true)
+	shell, holes, err = hull.GetHull()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 1, len(holes))
+	assert2.Equal(t, 36.0, signedRingArea(shell))
+	assert2.Equal(t, -14.0, signedRingArea(holes[0]))
+}
+
+func TestConcaveHullOfPolygons(t *testing.T) {
+	polygons := [][]Coordinate{
+		createLine(0, 0, 1, 0, 1, 1, 0, 1, 0, 0),
+		createLine(3, 0, 4, 0, 4, 1, 3, 1, 3, 0),
+		createLine(0, 3, 1, 3, 1, 4, 0, 4, 0, 3),
+	}
+	shell, holes, err := ConcaveHullOfPolygonsByLength(polygons, 0)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, len(holes))
+	// the squares joined by the gaps between them
+	assert2.Equal(t, 7.0, signedRingArea(shell))
+	for _, ring := range polygons {
+		for _, p := range ring {
+			assert2.True(t, indexOf(p, shell) >= 0)
+		}
+	}
+
+	shell, _, err = ConcaveHullOfPolygonsByLength(polygons, 10)
+	assert2.Nil(t, err)
+	assert2.InDelta(t, 11.5, math.Abs(signedRingArea(shell)), 1e-9)
+}
+
+func TestConcaveHullInvalidParameters(t *testing.T) {
+	hull := NewConcaveHull(createUPoints())
+	assert2.NotNil(t, hull.SetMaximumEdgeLength(-1))
+	assert2.NotNil(t, hull.SetMaximumEdgeLengthRatio(1.5))
+	assert2.NotNil(t, hull.SetMaximumEdgeLength(math.NaN()))
+	assert2.NotNil(t, hull.SetMaximumEdgeLengthRatio(math.NaN()))
+	square := [][]Coordinate{createLine(0, 0, 1, 0, 1, 1, 0, 1, 0, 0)}
+	_, _, err := ConcaveHullOfPolygonsByLength(square, math.NaN())
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	polygonsHull := NewConcaveHullOfPolygons(square)
+	assert2.True(t, errors.Is(polygonsHull.SetMaximumEdgeLengthRatio(math.NaN()), ErrInvalidArgument))
+	shell, _, err := ConcaveHullByLength(createLine(0, 0, 1, 1, 2, 2), 1)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, len(shell))
+}
+
+func TestConcaveHullOfPolygonsSharingEdge(t *testing.T) {
+	polygons := [][]Coordinate{
+		createLine(0, 0, 10, 0, 10, 10, 0, 10, 0, 0),
+		createLine(10, 2, 20, 2, 20, 8, 10, 8, 10, 2),
+	}
+	shell, holes, err := ConcaveHullOfPolygonsByLength(polygons, 0)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, len(holes))
+	assert2.Equal(t, 160.0, signedRingArea(shell))
+}
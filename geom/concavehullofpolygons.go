@@ -0,0 +1,135 @@
+package geom
+
+// Constructs a concave hull of a set of polygons, respecting
+// the polygons as constraints.
+// A concave hull is a possibly non-convex polygon containing all the input polygons.
+// The computed hull "fills the gap" between the polygons,
+// and does not intersect their interiors.
+//
+// The hull is constructed by removing the longest outer edges of the
+// constrained Delaunay Triangulation of the space between the polygons,
+// until the target criterion parameter is reached.
+// The maximum edge length parameters have the same meaning as for ConcaveHull,
+// but apply only to the triangles lying outside the polygons.
+//
+// Polygons are given as their shell rings. They must not overlap;
+// they may touch at vertices.
+type ConcaveHullOfPolygons struct {
+	polygons           [][]Coordinate
+	maxEdgeLength      float64
+	maxEdgeLengthRatio float64
+	isHolesAllowed     bool
+}
+
+// Creates a new instance for a given set of polygon shells,
+// with a maximum edge length of 0 (the tightest hull).
+func NewConcaveHullOfPolygons(polygons [][]Coordinate) ConcaveHullOfPolygons {
+	h := ConcaveHullOfPolygons{
+		polygons:           make([][]Coordinate, len(polygons)),
+		maxEdgeLengthRatio: -1,
+	}
+	for i, ring := range polygons {
+		h.polygons[i] = copyDeepCoordinateArray(ring)
+	}
+	return h
+}
+
+// Computes the concave hull of the polygons using
+// a given maximum edge length.
+// Returns the shell and holes of the hull.
+func ConcaveHullOfPolygonsByLength(polygons [][]Coordinate, maxLength float64) ([]Coordinate, [][]Coordinate, error) {
+	hull := NewConcaveHullOfPolygons(polygons)
+	if err := hull.SetMaximumEdgeLength(maxLength); err != nil {
+		return nil, nil, err
+	}
+	return hull.GetHull()
+}
+
+// Sets the target maximum edge length for the concave hull.
+// The length value must be zero or greater.
+func (h *ConcaveHullOfPolygons) SetMaximumEdgeLength(edgeLength float64) error {
+	if !(edgeLength >= 0) {
+		return invalidArgumentError("Edge length must be non-negative")
+	}
+	h.maxEdgeLength = edgeLength
+	h.maxEdgeLengthRatio = -1
+	return nil
+}
+
+// Sets the target maximum edge length ratio for the concave hull,
+// as a fraction in the range 0 to 1 of the difference between
+// the longest and shortest edge lengths of the triangles between the polygons.
+func (h *ConcaveHullOfPolygons) SetMaximumEdgeLengthRatio(edgeLengthRatio float64) error {
+	if !(edgeLengthRatio >= 0 && edgeLengthRatio <= 1) {
+		return invalidArgumentError("Edge length ratio must be in range [0,1]")
+	}
+	h.maxEdgeLengthRatio = edgeLengthRatio
+	return nil
+}
+
+// Sets whether holes are allowed in the concave hull polygon.
+func (h *ConcaveHullOfPolygons) SetHolesAllowed(isHolesAllowed bool) {
+	h.isHolesAllowed = isHolesAllowed
+}
+
+// Gets the computed concave hull, as a shell in counter-clockwise order
+// and a list of holes in clockwise order.
+func (h *ConcaveHullOfPolygons) GetHull() ([]Coordinate, [][]Coordinate, error) {
+	var sites []Coordinate
+	for _, ring := range h.polygons {
+		sites = append(sites, ring...)
+	}
+	builder := NewConformingDelaunayTriangulationBuilder()
+	builder.SetSites(sites)
+	builder.SetConstraints(h.polygons)
+	triangles, err := builder.GetTriangles()
+	if err != nil {
+		return nil, nil, err
+	}
+	hullTris := newHullTriangulation(triangles)
+	for _, tri := range hullTris.tris {
+		tri.isFixed = h.isInPolygon(tri)
+	}
+	maxEdgeLength := h.maxEdgeLength
+	if h.maxEdgeLengthRatio >= 0 {
+		maxEdgeLength = hullTris.computeTargetEdgeLength(h.maxEdgeLengthRatio)
+	}
+	hullTris.erodeBorder(maxEdgeLength)
+	if h.isHolesAllowed {
+		hullTris.removeHoles(maxEdgeLength)
+	}
+	shell, holes := hullTris.toRings()
+	return shell, holes, nil
+}
+
+// Tests whether a triangle lies inside one of the polygons,
+// using its centroid.
+func (h *ConcaveHullOfPolygons) isInPolygon(tri *hullTri) bool {
+	centroid := NewXYCoordinate(
+		(tri.p[0].x+tri.p[1].x+tri.p[2].x)/3,
+		(tri.p[0].y+tri.p[1].y+tri.p[2].y)/3)
+	for _, ring := range h.polygons {
+		if isPointInRing(centroid, ring) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tests whether a point lies inside a ring, using the crossing number
+// of a ray extending to the right of the point.
+// Points on the ring boundary may be reported as either inside or outside.
+func isPointInRing(p Coordinate, ring []Coordinate) bool {
+	isInside := false
+	for i := 1; i < len(ring); i++ {
+		p1 := ring[i-1]
+		p2 := ring[i]
+		if (p1.y > p.y) != (p2.y > p.y) {
+			xInt := p1.x + (p.y-p1.y)*(p2.x-p1.x)/(p2.y-p1.y)
+			if xInt > p.x {
+				isInside = !isInside
+			}
+		}
+	}
+	return isInside
+}
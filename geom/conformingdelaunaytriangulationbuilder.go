@@ -0,0 +1,368 @@
+package geom
+
+import (
+	"math"
+	"sort"
+)
+
+// The maximum number of rounds of constraint segment splitting
+// before the conforming triangulation is abandoned.
+const maxSplitIter = 99
+
+// The maximum number of vertices inserted to split the constraint segments,
+// as a multiple of the number of vertices and segments in the input,
+// before the conforming triangulation is abandoned.
+const maxSplitVertexFactor = 10
+
+// The fraction of the length of a constraint segment within which
+// a vertex is considered to lie on the segment.
+const onSegmentFraction = 1e-10
+
+// A utility class which creates Conforming Delaunay Triangulations
+// from collections of points and linear constraints, and extract the resulting
+// triangulation edges or triangles as Coordinate arrays.
+//
+// The constraint segments are present in the triangulation
+// as chains of edges: segments which are not edges of the Delaunay
+// triangulation of the sites are split until they are.
+// A segment is split at a vertex lying on it, if there is one,
+// and otherwise near the projection of the vertex which encroaches upon it.
+// The vertices of the constraints are added to the sites;
+// sites within the tolerance of a constraint vertex are dropped.
+type ConformingDelaunayTriangulationBuilder struct {
+	siteCoords  []Coordinate
+	constraints [][]Coordinate
+	tolerance   float64
+	subdiv      *QuadEdgeSubdivision
+}
+
+// Creates a new conforming triangulation builder.
+func NewConformingDelaunayTriangulationBuilder() ConformingDelaunayTriangulationBuilder {
+	return ConformingDelaunayTriangulationBuilder{}
+}
+
+// Sets the sites (point or vertices) which will be triangulated.
+func (b *ConformingDelaunayTriangulationBuilder) SetSites(coords []Coordinate) {
+	b.siteCoords = copyDeepCoordinateArray(coords)
+	b.subdiv = nil
+}
+
+// Sets the linear constraints to be conformed to.
+// The constraints are noded before they are triangulated,
+// so constraint lines may cross or overlap each other.
+func (b *ConformingDelaunayTriangulationBuilder) SetConstraints(lines [][]Coordinate) {
+	b.constraints = make([][]Coordinate, len(lines))
+	for i, pts := range lines {
+		b.constraints[i] = copyDeepCoordinateArray(pts)
+	}
+	b.subdiv = nil
+}
+
+// Sets the snapping tolerance which will be used
+// to improved the robustness of the triangulation computation.
+// A tolerance of 0.0 specifies that no snapping will take place.
+func (b *ConformingDelaunayTriangulationBuilder) SetTolerance(tolerance float64) {
+	b.tolerance = tolerance
+	b.subdiv = nil
+}
+
+func (b *ConformingDelaunayTriangulationBuilder) create() error {
+	if b.subdiv != nil {
+		return nil
+	}
+	var constraintVertices []Coordinate
+	for _, pts := range b.constraints {
+		constraintVertices = append(constraintVertices, pts...)
+	}
+	constraintVertices = uniqueCoordinates(constraintVertices, b.tolerance)
+	sites := b.createSiteVertices(constraintVertices)
+
+	env := envelope(sites)
+	env.expandToIncludeEnvelope(envelope(constraintVertices))
+	subdiv := NewQuadEdgeSubdivision(env, b.tolerance)
+	enforcer := newConstraintEnforcer(subdiv, env, len(sites)+len(constraintVertices))
+	for _, site := range sites {
+		if _, err := enforcer.insertVertex(site); err != nil {
+			return err
+		}
+	}
+
+	var constraintSegments []LineSegment
+	for _, pts := range b.constraints {
+		pts = removeRepeatedPoints(pts)
+		for i := 1; i < len(pts); i++ {
+			constraintSegments = append(constraintSegments, NewLineSegment(pts[i-1], pts[i]))
+		}
+	}
+
+	// the noded constraint segments, with their end points
+	// snapped to the vertices of the subdivision
+	var segments []LineSegment
+	seen := make(map[[2]CoordinateKey]bool)
+	for _, seg := range nodeSegments(constraintSegments, b.tolerance) {
+		p0, err := enforcer.insertVertex(seg.p0)
+		if err != nil {
+			return err
+		}
+		p1, err := enforcer.insertVertex(seg.p1)
+		if err != nil {
+			return err
+		}
+		key := [2]CoordinateKey{NewCoordinateKey(p0), NewCoordinateKey(p1)}
+		if p0.equals2D(&p1) || seen[key] {
+			continue
+		}
+		seen[key] = true
+		seen[[2]CoordinateKey{key[1], key[0]}] = true
+		segments = append(segments, NewLineSegment(p0, p1))
+	}
+	if err := enforcer.enforceConstraints(segments); err != nil {
+		return err
+	}
+	b.subdiv = subdiv
+	return nil
+}
+
+// Gets the QuadEdgeSubdivision which models the computed triangulation.
+func (b *ConformingDelaunayTriangulationBuilder) GetSubdivision() (*QuadEdgeSubdivision, error) {
+	if err := b.create(); err != nil {
+		return nil, err
+	}
+	return b.subdiv, nil
+}
+
+// Gets the edges of the computed triangulation,
+// each as a Coordinate array of 2 points.
+func (b *ConformingDelaunayTriangulationBuilder) GetEdges() ([][]Coordinate, error) {
+	if err := b.create(); err != nil {
+		return nil, err
+	}
+	return b.subdiv.getEdgeCoordinates(), nil
+}
+
+// Gets the faces of the computed triangulation,
+// each as a closed ring of 4 Coordinate(s) in counter-clockwise order.
+func (b *ConformingDelaunayTriangulationBuilder) GetTriangles() ([][]Coordinate, error) {
+	if err := b.create(); err != nil {
+		return nil, err
+	}
+	return b.subdiv.getTriangleCoordinates(false), nil
+}
+
+// Gets the sites which are not within the tolerance of a constraint vertex.
+func (b *ConformingDelaunayTriangulationBuilder) createSiteVertices(constraintVertices []Coordinate) []Coordinate {
+	var sites []Coordinate
+	for _, c := range uniqueCoordinates(b.siteCoords, b.tolerance) {
+		isConstraintVertex := false
+		for i := range constraintVertices {
+			if c.equals2DWithTolerance(&constraintVertices[i], b.tolerance) {
+				isConstraintVertex = true
+				break
+			}
+		}
+		if !isConstraintVertex {
+			sites = append(sites, c)
+		}
+	}
+	return sites
+}
+
+// Inserts vertices into a Delaunay triangulation to split constraint segments,
+// until the triangulation conforms to them.
+// The vertices are indexed to find those encroaching upon a segment,
+// that is, lying inside the circle whose diameter is the segment.
+type constraintEnforcer struct {
+	triangulator incrementalDelaunayTriangulator
+	vertices     []Coordinate
+	index        gridIndex
+}
+
+func newConstraintEnforcer(subdiv *QuadEdgeSubdivision, env Envelope, size int) constraintEnforcer {
+	return constraintEnforcer{
+		triangulator: newIncrementalDelaunayTriangulator(subdiv),
+		index:        newGridIndex(env, size),
+	}
+}
+
+// Creates an error for the failure to conform to constraint segments.
+func newConstraintTopologyError(pt Coordinate, msg string, segments ...LineSegment) *TopologyError {
+	err := NewTopologyError("conforming Delaunay triangulation", pt, msg)
+	for _, seg := range segments {
+		err.addInputLines([]Coordinate{seg.p0, seg.p1})
+	}
+	return err
+}
+
+// Inserts a vertex and returns the vertex which represents it
+// in the subdivision.
+func (e *constraintEnforcer) insertVertex(v Coordinate) (Coordinate, error) {
+	inserted, err := e.triangulator.insertVertex(v)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	if inserted.equals2D(&v) {
+		e.vertices = append(e.vertices, inserted)
+		e.index.insert(NewPointEnvelope(inserted))
+	}
+	return inserted, nil
+}
+
+// Splits the constraint segments which are not edges of the subdivision,
+// until every segment is present.
+// Returns a TopologyError if the splitting takes too many rounds
+// or inserts too many vertices.
+func (e *constraintEnforcer) enforceConstraints(segments []LineSegment) error {
+	maxVertices := len(e.vertices) + maxSplitVertexFactor*(len(e.vertices)+len(segments))
+	for iter := 0; len(segments) > 0; iter++ {
+		if iter > maxSplitIter {
+			return newConstraintTopologyError(segments[0].p0, "too many splitting iterations", segments...)
+		}
+		var missing []LineSegment
+		for _, seg := range segments {
+			if e.triangulator.subdiv.findEdge(seg.p0, seg.p1) != nil {
+				continue
+			}
+			if len(e.vertices) >= maxVertices {
+				return newConstraintTopologyError(seg.p0, "too many split vertices", seg)
+			}
+			splitPt, err := e.insertVertex(e.findSplitPoint(seg))
+			if err != nil {
+				return err
+			}
+			if splitPt.equals2D(&seg.p0) || splitPt.equals2D(&seg.p1) {
+				return newConstraintTopologyError(splitPt, "unable to split constraint segment", seg)
+			}
+			missing = append(missing, NewLineSegment(seg.p0, splitPt), NewLineSegment(splitPt, seg.p1))
+		}
+		segments = missing
+	}
+	return nil
+}
+
+// Finds the point at which to split a segment, following
+// the NonEncroachingSplitPointFinder of JTS.
+// A vertex lying on the segment is used as it is.
+// Otherwise the segment is split at the projection of the encroaching vertex
+// closest to its midpoint, moved away from the end points if need be
+// so that neither half is still encroached upon by that vertex.
+// A segment with no encroaching vertex is split at its midpoint.
+func (e *constraintEnforcer) findSplitPoint(seg LineSegment) Coordinate {
+	midPt := seg.midPoint()
+	radius := seg.p0.distance(&midPt)
+	env := NewPointEnvelope(midPt)
+	env.expandBy(radius, radius)
+
+	var encroachPt, onSegmentPt *Coordinate
+	minDist := math.Inf(1)
+	minOnSegmentDist := math.Inf(1)
+	e.index.query(env, func(item int) {
+		v := &e.vertices[item]
+		if v.equals2D(&seg.p0) || v.equals2D(&seg.p1) {
+			return
+		}
+		dist := midPt.distance(v)
+		if dist >= radius {
+			return
+		}
+		if isOnSegment(seg, *v, e.triangulator.subdiv.tolerance) {
+			if dist < minOnSegmentDist {
+				onSegmentPt = v
+				minOnSegmentDist = dist
+			}
+		} else if dist < minDist {
+			encroachPt = v
+			minDist = dist
+		}
+	})
+	if onSegmentPt != nil {
+		return *onSegmentPt
+	}
+	if encroachPt == nil {
+		return midPt
+	}
+
+	projPt := seg.project(*encroachPt)
+	segLen := seg.getLength()
+	// the largest distance from the end points which keeps
+	// the encroaching vertex outside of the circles of both halves,
+	// reduced slightly for safety
+	minLen := math.Min(projPt.distance(encroachPt)*2*0.8, segLen/2)
+	if projPt.distance(&seg.p0) < minLen {
+		return seg.pointAlong(minLen / segLen)
+	}
+	if projPt.distance(&seg.p1) < minLen {
+		return seg.pointAlong(1 - minLen/segLen)
+	}
+	return projPt
+}
+
+// Tests whether a point lies on a segment, within a tolerance
+// or a tiny fraction of the segment length to absorb round-off.
+func isOnSegment(seg LineSegment, p Coordinate, tolerance float64) bool {
+	return seg.distance(p) <= math.Max(tolerance, onSegmentFraction*seg.getLength())
+}
+
+// Nodes a set of segments: splits them at their intersections,
+// and at the end points of other segments lying on them
+// within a tolerance.
+func nodeSegments(segments []LineSegment, tolerance float64) []LineSegment {
+	env := NewEmptyEnvelope()
+	for _, seg := range segments {
+		env.expandToIncludeEnvelope(NewEnvelopeFromCoordinates(seg.p0, seg.p1))
+	}
+	index := newGridIndex(env, len(segments))
+	for _, seg := range segments {
+		index.insert(NewEnvelopeFromCoordinates(seg.p0, seg.p1))
+	}
+
+	nodes := make([][]Coordinate, len(segments))
+	for i := range segments {
+		a := segments[i]
+		queryEnv := NewEnvelopeFromCoordinates(a.p0, a.p1)
+		queryEnv.expandBy(tolerance, tolerance)
+		index.query(queryEnv, func(j int) {
+			if j <= i {
+				return
+			}
+			b := segments[j]
+			touches := false
+			for _, p := range [2]Coordinate{b.p0, b.p1} {
+				if isOnSegment(a, p, tolerance) {
+					nodes[i] = append(nodes[i], p)
+					touches = true
+				}
+			}
+			for _, p := range [2]Coordinate{a.p0, a.p1} {
+				if isOnSegment(b, p, tolerance) {
+					nodes[j] = append(nodes[j], p)
+					touches = true
+				}
+			}
+			if touches {
+				return
+			}
+			if pt, ok := a.intersection(b); ok {
+				nodes[i] = append(nodes[i], pt)
+				nodes[j] = append(nodes[j], pt)
+			}
+		})
+	}
+
+	var noded []LineSegment
+	for i, seg := range segments {
+		segNodes := nodes[i]
+		sort.Slice(segNodes, func(a, b int) bool {
+			return seg.projectionFactor(segNodes[a]) < seg.projectionFactor(segNodes[b])
+		})
+		start := seg.p0
+		for _, p := range segNodes {
+			if p.equals2DWithTolerance(&start, tolerance) || p.equals2DWithTolerance(&seg.p1, tolerance) {
+				continue
+			}
+			noded = append(noded, NewLineSegment(start, p))
+			start = p
+		}
+		noded = append(noded, NewLineSegment(start, seg.p1))
+	}
+	return noded
+}
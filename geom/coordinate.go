@@ -1,7 +1,6 @@
 package geom
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -59,20 +58,37 @@ func (c *Coordinate) setCoordinate(other *Coordinate) {
 	c.z = other.z
 }
 
+// Gets the X ordinate value.
+func (c *Coordinate) GetX() float64 {
+	return c.x
+}
+
+// Gets the Y ordinate value.
+func (c *Coordinate) GetY() float64 {
+	return c.y
+}
+
+// Gets the Z ordinate value, which is NaN if it is not specified.
+func (c *Coordinate) GetZ() float64 {
+	return c.z
+}
+
 // Gets the ordinate value for the given index.
-func (c *Coordinate) getOrdinate(ordinateIndex int) (error, float64) {
+// An invalid index gives an error wrapping ErrInvalidOrdinate.
+func (c *Coordinate) getOrdinate(ordinateIndex int) (float64, error) {
 	switch ordinateIndex {
 	case X:
-		return nil, c.x
+		return c.x, nil
 	case Y:
-		return nil, c.y
+		return c.y, nil
 	case Z:
-		return nil, c.z
+		return c.z, nil
 	}
-	return errors.New("Invalid ordinate index: " + strconv.Itoa(ordinateIndex)), 0
+	return 0, NewCoordinateError(ErrInvalidOrdinate, *c, strconv.Itoa(ordinateIndex))
 }
 
 // Sets the ordinate for the given index to a given value.
+// An invalid index gives an error wrapping ErrInvalidOrdinate.
 func (c *Coordinate) setOrdinate(ordinateIndex int, value float64) error {
 	switch ordinateIndex {
 	case X:
@@ -85,7 +101,7 @@ func (c *Coordinate) setOrdinate(ordinateIndex int, value float64) error {
 		c.z = value
 		return nil
 	}
-	return errors.New("Invalid ordinate index: " + strconv.Itoa(ordinateIndex))
+	return NewCoordinateError(ErrInvalidOrdinate, *c, strconv.Itoa(ordinateIndex))
 }
 
 // Returns whether the planar projections of the two Coordinates
@@ -182,3 +198,16 @@ func (c *Coordinate) clone() Coordinate {
 		dimensions: c.dimensions,
 	}
 }
+
+// Computes a hash code for this coordinate, which is consistent with equals2D:
+// the Z ordinate is ignored and -0 hashes the same as 0.
+// The hash code is stable across runs and platforms.
+func (c *Coordinate) Hash() uint64 {
+	return hashOrdinates(c.x, c.y)
+}
+
+// Computes a hash code for this coordinate, which is consistent with equals3D:
+// the Z ordinate is included, and all NaN values of Z hash the same.
+func (c *Coordinate) Hash3D() uint64 {
+	return hashOrdinates(c.x, c.y, c.z)
+}
@@ -0,0 +1,117 @@
+package geom
+
+// A CoordinateFilter that extracts all the coordinates it is given,
+// in the order in which it is given them.
+type CoordinateArrayFilter struct {
+	pts []Coordinate
+}
+
+// Creates a filter with no coordinates collected.
+func NewCoordinateArrayFilter() CoordinateArrayFilter {
+	return CoordinateArrayFilter{
+		pts: []Coordinate{},
+	}
+}
+
+// Adds a copy of the coordinate to the collected coordinates.
+func (f *CoordinateArrayFilter) Filter(coord *Coordinate) {
+	f.pts = append(f.pts, *coord)
+}
+
+// Returns the gathered Coordinate(s).
+func (f *CoordinateArrayFilter) GetCoordinates() []Coordinate {
+	return f.pts
+}
+
+// A CoordinateFilter that extracts a unique array of coordinates.
+// The array of coordinates contains no duplicate points,
+// compared in 2D.
+// It preserves the order of the input points.
+type UniqueCoordinateArrayFilter struct {
+	seen map[CoordinateKey]bool
+	pts  []Coordinate
+}
+
+// Creates a filter with no coordinates collected.
+func NewUniqueCoordinateArrayFilter() UniqueCoordinateArrayFilter {
+	return UniqueCoordinateArrayFilter{
+		seen: make(map[CoordinateKey]bool),
+		pts:  []Coordinate{},
+	}
+}
+
+// Convenience method which allows running the filter over an array of Coordinate(s).
+// Returns the unique coordinates, in the order in which they first occur.
+func FilterUniqueCoordinates(pts []Coordinate) []Coordinate {
+	filter := NewUniqueCoordinateArrayFilter()
+	ApplyCoordinateFilter(pts, &filter)
+	return filter.GetCoordinates()
+}
+
+// Adds a copy of the coordinate to the collected coordinates,
+// unless an equal coordinate has already been collected.
+func (f *UniqueCoordinateArrayFilter) Filter(coord *Coordinate) {
+	key := CoordinateKey{coord.x, coord.y}
+	if f.seen[key] {
+		return
+	}
+	f.seen[key] = true
+	f.pts = append(f.pts, *coord)
+}
+
+// Returns the gathered Coordinate(s).
+func (f *UniqueCoordinateArrayFilter) GetCoordinates() []Coordinate {
+	return f.pts
+}
+
+// A CoordinateFilter that computes the Envelope
+// of the coordinates it is given.
+type EnvelopeFilter struct {
+	env Envelope
+}
+
+// Creates a filter with an empty Envelope.
+func NewEnvelopeFilter() EnvelopeFilter {
+	return EnvelopeFilter{
+		env: NewEmptyEnvelope(),
+	}
+}
+
+// Expands the envelope to include the coordinate.
+func (f *EnvelopeFilter) Filter(coord *Coordinate) {
+	f.env.expandToIncludeCoordinate(*coord)
+}
+
+// Returns the Envelope of the coordinates.
+func (f *EnvelopeFilter) GetEnvelope() Envelope {
+	return f.env
+}
+
+// A CoordinateSequenceFilter which rounds the coordinates
+// to a given PrecisionModel.
+// Repeated points created by the rounding are not removed.
+type CoordinatePrecisionReducerFilter struct {
+	precModel PrecisionModel
+}
+
+// Creates a new precision reducer filter.
+func NewCoordinatePrecisionReducerFilter(precModel PrecisionModel) CoordinatePrecisionReducerFilter {
+	return CoordinatePrecisionReducerFilter{
+		precModel: precModel,
+	}
+}
+
+// Rounds the coordinate at position i.
+func (f *CoordinatePrecisionReducerFilter) Filter(seq []Coordinate, i int) {
+	f.precModel.makePreciseCoordinate(&seq[i])
+}
+
+// Always runs over all coordinates.
+func (f *CoordinatePrecisionReducerFilter) IsDone() bool {
+	return false
+}
+
+// Always reports that the coordinates have changed.
+func (f *CoordinatePrecisionReducerFilter) IsGeometryChanged() bool {
+	return true
+}
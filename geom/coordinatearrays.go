@@ -1,5 +1,7 @@
 package geom
 
+import "math"
+
 func dimension(pts []Coordinate) int {
 	if pts == nil || len(pts) == 0 {
 		return 3 //unknown, assume default
@@ -106,7 +108,7 @@ func increasingDirection(pts []Coordinate) int {
 // are equal in opposite directions.
 func isEqualReversed(pts1, pts2 []Coordinate) bool {
 	for i, c := range pts1 {
-		if c.compareTo(pts2[i]) != 0 {
+		if c.compareTo(pts2[len(pts2)-1-i]) != 0 {
 			return false
 		}
 	}
@@ -119,10 +121,10 @@ func copyDeepCoordinateArray(coordinates []Coordinate) []Coordinate {
 	return result
 }
 
-// Returns whether two consecutive Coordinates equal
+// Returns whether two consecutive Coordinates are equal in 2D
 func hasRepeatedPoints(pts []Coordinate) bool {
-	for i, _ := range pts {
-		if pts[i-1] == pts[i] {
+	for i := 1; i < len(pts); i++ {
+		if pts[i-1].equals2D(&pts[i]) {
 			return true
 		}
 	}
@@ -132,17 +134,17 @@ func hasRepeatedPoints(pts []Coordinate) bool {
 // If the coordinate array argument has repeated points,
 // constructs a new array containing no repeated points.
 // Otherwise, returns the argument.
+// Only consecutive points which are equal in 2D are considered repeated.
 func removeRepeatedPoints(pts []Coordinate) []Coordinate {
 	if !hasRepeatedPoints(pts) {
 		return pts
 	}
-	keys := make(map[Coordinate]bool)
-	var result []Coordinate
-	for _, c := range pts {
-		if _, v := keys[c]; !v {
-			keys[c] = true
-			result = append(result, c)
+	result := make([]Coordinate, 0, len(pts))
+	for i := range pts {
+		if i > 0 && pts[i].equals2D(&result[len(result)-1]) {
+			continue
 		}
+		result = append(result, pts[i])
 	}
 	return result
 }
@@ -174,12 +176,21 @@ func reverse(pts []Coordinate) {
 //
 // If ensureRing is true, first and last
 // coordinate of the returned array are equal.
-func scroll(coordinates []Coordinate, indexOfFirstCoordinate int, ensureRing bool) {
+// An index outside of the array gives an error wrapping ErrIndexOutOfRange,
+// and requesting a ring for an array which is not a ring
+// gives an error wrapping ErrNotARing.
+func scroll(coordinates []Coordinate, indexOfFirstCoordinate int, ensureRing bool) error {
 	i := indexOfFirstCoordinate
-	if i <= 0 {
-		return
-	}
 	l := len(coordinates)
+	if i < 0 || i >= l {
+		return indexOutOfRangeError(i, l)
+	}
+	if ensureRing && !isRing(coordinates) {
+		return NewCoordinateError(ErrNotARing, coordinates[0], "cannot scroll as a ring")
+	}
+	if i == 0 {
+		return nil
+	}
 	result := make([]Coordinate, l, l)
 	if !ensureRing {
 		copy(result[:l-i], coordinates[i:l])
@@ -195,10 +206,11 @@ func scroll(coordinates []Coordinate, indexOfFirstCoordinate int, ensureRing boo
 		result[j] = result[0].clone()
 	}
 	copy(coordinates, result)
+	return nil
 }
 
-func scrollWithRingCheck(coordinates []Coordinate, indexOfFirstCoordinate int) {
-	scroll(coordinates, indexOfFirstCoordinate, isRing(coordinates))
+func scrollWithRingCheck(coordinates []Coordinate, indexOfFirstCoordinate int) error {
+	return scroll(coordinates, indexOfFirstCoordinate, isRing(coordinates))
 }
 
 // Returns the index of Coordinate in Coordinates slice.
@@ -214,27 +226,23 @@ func indexOf(coordinate Coordinate, coordinates []Coordinate) int {
 
 // Extracts a subsequence of the input Coordinate array
 // from indices start to end (inclusive).
-// The input indices are clamped to the array size;
 // If the end index is less than the start index,
 // the extracted array will be empty.
-func extract(pts []Coordinate, start, end int) []Coordinate {
-	start = clamp(start, 0, len(pts))
-	end = clamp(end, -1, len(pts))
-	npts := end - start + 1
-	if end < 0 {
-		npts = 0
+// Indices outside of the array give an error wrapping ErrIndexOutOfRange;
+// start may equal the array length to extract an empty array at the end.
+func extract(pts []Coordinate, start, end int) ([]Coordinate, error) {
+	if start < 0 || start > len(pts) {
+		return nil, indexOutOfRangeError(start, len(pts))
 	}
-	if start >= len(pts) {
-		npts = 0
+	if end < -1 || end >= len(pts) {
+		return nil, indexOutOfRangeError(end, len(pts))
 	}
 	if end < start {
-		npts = 0
-	}
-	var extractPts []Coordinate
-	if npts == 0 {
-		return extractPts
+		return make([]Coordinate, 0), nil
 	}
-	return pts[start:end]
+	extractPts := make([]Coordinate, end-start+1)
+	copy(extractPts, pts[start:end+1])
+	return extractPts, nil
 }
 
 // Computes the Envelope of the coordinates.
@@ -258,7 +266,7 @@ func intersection(coordinates []Coordinate, env Envelope) []Coordinate {
 }
 
 func equalArrays(coord1, coord2 []Coordinate) bool {
-	if (coord1 == nil || len(coord1) == 0) && (coord1 == nil || len(coord2) == 0) {
+	if len(coord1) == 0 && len(coord2) == 0 {
 		return true
 	}
 	if len(coord1) != len(coord2) {
@@ -271,3 +279,78 @@ func equalArrays(coord1, coord2 []Coordinate) bool {
 	}
 	return true
 }
+
+// Densifies a Coordinate array by inserting extra vertices along the segments.
+// Each segment is split into the smallest number of equal-length subsegments
+// which are no longer than maxSegmentLength.
+// The Z ordinate of the inserted vertices is interpolated along the segments.
+// Repeated points are removed.
+func Densify(pts []Coordinate, maxSegmentLength float64) ([]Coordinate, error) {
+	if err := checkDensifyTolerance(maxSegmentLength); err != nil {
+		return nil, err
+	}
+	return densifyPoints(pts, maxSegmentLength, nil), nil
+}
+
+// Checks that a densification tolerance is positive.
+func checkDensifyTolerance(tolerance float64) error {
+	if !(tolerance > 0) {
+		return invalidArgumentError("Densification tolerance must be positive: %g", tolerance)
+	}
+	return nil
+}
+
+// Densifies a Coordinate array, rounding the inserted vertices
+// to a PrecisionModel if one is given.
+func densifyPoints(pts []Coordinate, maxSegmentLength float64, pm *PrecisionModel) []Coordinate {
+	if len(pts) == 0 {
+		return make([]Coordinate, 0)
+	}
+	result := make([]Coordinate, 0, len(pts))
+	for i := 0; i < len(pts)-1; i++ {
+		p0 := pts[i]
+		p1 := pts[i+1]
+		result = append(result, p0)
+		segLen := p0.distance(&p1)
+		// skip segments which are too short to densify
+		if segLen <= maxSegmentLength {
+			continue
+		}
+		densifiedSegCount := int(math.Ceil(segLen / maxSegmentLength))
+		for j := 1; j < densifiedSegCount; j++ {
+			p := pointAlongSegmentByFraction(p0, p1, float64(j)/float64(densifiedSegCount))
+			if pm != nil {
+				pm.makePreciseCoordinate(&p)
+			}
+			result = append(result, p)
+		}
+	}
+	result = append(result, pts[len(pts)-1])
+	return removeRepeatedPoints(result)
+}
+
+// Computes the Coordinate lying a given fraction of the length along a line.
+// The fraction is clamped to the range [0.0, 1.0];
+// the Z ordinate is interpolated along the segment containing the point.
+// An empty array gives an error wrapping ErrEmpty.
+func Interpolate(pts []Coordinate, fraction float64) (Coordinate, error) {
+	if len(pts) == 0 {
+		return Coordinate{}, ErrEmpty
+	}
+	fraction = math.Max(0, math.Min(1, fraction))
+	loc := locationOfLength(pts, fraction*lengthOfLine(pts))
+	return loc.getCoordinate(pts), nil
+}
+
+// Computes the Z value of a point by interpolating along the segment p0-p1,
+// in proportion to the distance of the point from p0.
+// The point is assumed to lie on the segment; if the segment
+// has zero length the Z value of p0 is returned.
+func InterpolateZ(p, p0, p1 Coordinate) float64 {
+	segLen := p0.distance(&p1)
+	if segLen <= 0 {
+		return p0.z
+	}
+	ptLen := p.distance(&p0)
+	return p0.z + (p1.z-p0.z)*(ptLen/segLen)
+}
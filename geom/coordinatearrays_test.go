@@ -1,6 +1,7 @@
 package geom
 
 import (
+	"errors"
 	assert2 "github.com/stretchr/testify/assert"
 	"math"
 	"testing"
@@ -103,7 +104,7 @@ func createCircularString(center Coordinate, radius, startAngle float64, numpoin
 	angleCircle := 2 * math.Pi
 	angleStep := angleCircle / float64(numSegmentsCircle)
 	sequence := make([]Coordinate, numpoints)
-	pm := NewFixedPrecisionModel(1000)
+	pm := mustFixedPrecisionModel(1000)
 	angle := startAngle
 	for i := 0; i < numpoints; i++ {
 		dx := math.Cos(angle) * radius
@@ -116,11 +117,101 @@ func createCircularString(center Coordinate, radius, startAngle float64, numpoin
 }
 
 func createCircle(center Coordinate, radius float64) []Coordinate {
-	// Get a complete circular string
-	result := createCircularString(center, radius, 0.0, 49)
+	gsf := NewGeometricShapeFactoryFromPrecisionModel(mustFixedPrecisionModel(1000))
+	gsf.SetCentre(center)
+	gsf.SetSize(2 * radius)
+	gsf.SetNumPoints(48)
+	return gsf.CreateCircle()
+}
+
+func TestIsEqualReversed(t *testing.T) {
+	assert := assert2.New(t)
+	pts := createLine(1, 1, 2, 2, 3, 3)
+	reversed := createLine(3, 3, 2, 2, 1, 1)
+	assert.True(isEqualReversed(pts, reversed))
+	assert.False(isEqualReversed(pts, pts))
+}
+
+func TestScrollInvalid(t *testing.T) {
+	pts := createLine(0, 0, 1, 0, 1, 1)
+	assert2.True(t, errors.Is(scroll(pts, -1, false), ErrIndexOutOfRange))
+	assert2.True(t, errors.Is(scroll(pts, 3, false), ErrIndexOutOfRange))
+	err := scroll(pts, 1, true)
+	assert2.True(t, errors.Is(err, ErrNotARing))
+	var coordErr *CoordinateError
+	assert2.True(t, errors.As(err, &coordErr))
+	assert2.True(t, coordErr.Pt.equals2D(&pts[0]))
+	assert2.True(t, equalArrays(createLine(0, 0, 1, 0, 1, 1), pts))
+	assert2.Nil(t, scroll(pts, 1, false))
+	assert2.True(t, equalArrays(createLine(1, 0, 1, 1, 0, 0), pts))
+}
+
+func TestExtract(t *testing.T) {
+	assert := assert2.New(t)
+	pts := createLine(1, 1, 2, 2, 3, 3, 4, 4)
+	extracted, err := extract(pts, 1, 2)
+	assert.Nil(err)
+	assert.True(equalArrays(createLine(2, 2, 3, 3), extracted))
+	extracted, err = extract(pts, 2, 1)
+	assert.Nil(err)
+	assert.Empty(extracted)
+	extracted, err = extract(pts, 4, 3)
+	assert.Nil(err)
+	assert.Empty(extracted)
+	_, err = extract(pts, 2, 10)
+	assert.True(errors.Is(err, ErrIndexOutOfRange))
+	_, err = extract(pts, -5, 0)
+	assert.True(errors.Is(err, ErrIndexOutOfRange))
+	_, err = extract(pts, 4, 5)
+	assert.True(errors.Is(err, ErrIndexOutOfRange))
+}
 
-	// ensure it is closed
-	result[48] = result[0].clone()
+func TestRemoveRepeatedPoints(t *testing.T) {
+	assert2.False(t, hasRepeatedPoints(COORDS_EMPTY))
+	assert2.False(t, hasRepeatedPoints(COORDS_1))
+	pts := createLine(0, 0, 0, 0, 1, 1, 0, 0, 0, 0)
+	assert2.True(t, hasRepeatedPoints(pts))
+	// only consecutive repeated points are removed
+	assert2.True(t, equalArrays(createLine(0, 0, 1, 1, 0, 0), removeRepeatedPoints(pts)))
+	// NaN Z values do not prevent repeated points being found
+	assert2.Equal(t, 1, len(removeRepeatedPoints([]Coordinate{NewXYCoordinate(1, 1), NewXYCoordinate(1, 1)})))
+}
+
+func TestDensify(t *testing.T) {
+	densified, err := Densify(createLine(0, 0, 10, 0, 10, 1), 3)
+	assert2.Nil(t, err)
+	assert2.True(t, equalArrays(createLine(0, 0, 2.5, 0, 5, 0, 7.5, 0, 10, 0, 10, 1), densified))
+
+	densified, err = Densify([]Coordinate{NewCoordinate(0, 0, 0), NewCoordinate(0, 4, 8)}, 2)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 3, len(densified))
+	assert2.Equal(t, 4.0, densified[1].z)
+
+	// repeated points are removed
+	densified, err = Densify(createLine(0, 0, 0, 0, 1, 0), 2)
+	assert2.Nil(t, err)
+	assert2.True(t, equalArrays(createLine(0, 0, 1, 0), densified))
+
+	_, err = Densify(createLine(0, 0, 1, 0), 0)
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func TestInterpolate(t *testing.T) {
+	pts := []Coordinate{NewCoordinate(0, 0, 0), NewCoordinate(10, 0, 10), NewCoordinate(10, 10, 30)}
+	p, err := Interpolate(pts, 0.75)
+	assert2.Nil(t, err)
+	assert2.True(t, p.equals3D(&Coordinate{x: 10, y: 5, z: 20}))
+	p, _ = Interpolate(pts, -1)
+	assert2.True(t, p.equals3D(&pts[0]))
+	p, _ = Interpolate(pts, 2)
+	assert2.True(t, p.equals3D(&pts[2]))
+	_, err = Interpolate(nil, 0.5)
+	assert2.True(t, errors.Is(err, ErrEmpty))
+}
 
-	return result
+func TestInterpolateZ(t *testing.T) {
+	p0 := NewCoordinate(0, 0, 10)
+	p1 := NewCoordinate(4, 0, 20)
+	assert2.Equal(t, 12.5, InterpolateZ(NewXYCoordinate(1, 0), p0, p1))
+	assert2.Equal(t, 10.0, InterpolateZ(NewXYCoordinate(1, 0), p0, p0))
 }
@@ -0,0 +1,228 @@
+package geom
+
+import (
+	"math"
+	"sort"
+)
+
+// Compares two Coordinate(s) for order.
+// Returns -1, 0 or 1 as the first coordinate is less than,
+// equal to or greater than the second.
+type CoordinateComparator interface {
+	Compare(c1, c2 *Coordinate) int
+}
+
+// Compares two Coordinate(s), allowing for either a 2-dimensional
+// or 3-dimensional comparison, and handling NaN values correctly.
+type DimensionalComparator struct {
+	dimensionsToTest int
+}
+
+// Creates a comparator for 2 dimensional coordinates.
+func NewDefaultDimensionalComparator() DimensionalComparator {
+	return DimensionalComparator{
+		dimensionsToTest: 2,
+	}
+}
+
+// Creates a comparator for 2 or 3 dimensional coordinates, depending
+// on the value provided.
+func NewDimensionalComparator(dimensionsToTest int) (DimensionalComparator, error) {
+	if dimensionsToTest != 2 && dimensionsToTest != 3 {
+		return DimensionalComparator{}, invalidArgumentError("Only 2 or 3 dimensions may be specified: %d", dimensionsToTest)
+	}
+	return DimensionalComparator{
+		dimensionsToTest: dimensionsToTest,
+	}, nil
+}
+
+// Compare two float64s, allowing for NaN values.
+// NaN is treated as being less than any valid number.
+func compareOrdinates(a, b float64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	if math.IsNaN(a) {
+		if math.IsNaN(b) {
+			return 0
+		}
+		return -1
+	}
+	if math.IsNaN(b) {
+		return 1
+	}
+	return 0
+}
+
+// Compares two Coordinate(s) along to the number of
+// dimensions specified.
+func (c DimensionalComparator) Compare(c1, c2 *Coordinate) int {
+	if compX := compareOrdinates(c1.x, c2.x); compX != 0 {
+		return compX
+	}
+	if compY := compareOrdinates(c1.y, c2.y); compY != 0 {
+		return compY
+	}
+	if c.dimensionsToTest <= 2 {
+		return 0
+	}
+	return compareOrdinates(c1.z, c2.z)
+}
+
+// Compares two Coordinate(s) in 2D, treating ordinates which differ
+// by no more than a tolerance as equal.
+// Note that this ordering is not transitive for points closer together
+// than the tolerance, so it is only suitable for sorting data which
+// has no such clusters, or where their relative order is unimportant.
+type ToleranceComparator struct {
+	tolerance float64
+}
+
+// Creates a comparator which uses a tolerance.
+// Returns ErrInvalidArgument if the tolerance is negative or NaN.
+func NewToleranceComparator(tolerance float64) (ToleranceComparator, error) {
+	if !(tolerance >= 0) {
+		return ToleranceComparator{}, invalidArgumentError("Tolerance must not be negative: %g", tolerance)
+	}
+	return ToleranceComparator{
+		tolerance: tolerance,
+	}, nil
+}
+
+// Compares two Coordinate(s) by X and then Y, up to the tolerance.
+func (c ToleranceComparator) Compare(c1, c2 *Coordinate) int {
+	if !EqualsWithTolerance(c1.x, c2.x, c.tolerance) {
+		return compareOrdinates(c1.x, c2.x)
+	}
+	if !EqualsWithTolerance(c1.y, c2.y, c.tolerance) {
+		return compareOrdinates(c1.y, c2.y)
+	}
+	return 0
+}
+
+// Compares two Coordinate arrays for order.
+type CoordinateArrayComparator interface {
+	Compare(pts1, pts2 []Coordinate) int
+}
+
+// A comparator for Coordinate arrays in the forward direction of their coordinates,
+// using lexicographic ordering.
+type ForwardComparator struct{}
+
+// Compares the arrays in the forward direction.
+func (ForwardComparator) Compare(pts1, pts2 []Coordinate) int {
+	return compare(pts1, pts2)
+}
+
+// A comparator for Coordinate arrays modulo their directionality.
+// E.g. if two coordinate arrays are identical but reversed
+// they will compare as equal under this ordering.
+// Each array is compared in the direction given by increasingDirection,
+// which makes the ordering consistent.
+type BidirectionalComparator struct{}
+
+// Compares the arrays, each in its canonical direction.
+func (BidirectionalComparator) Compare(pts1, pts2 []Coordinate) int {
+	return compareOriented(pts1, increasingDirection(pts1) == 1, pts2, increasingDirection(pts2) == 1)
+}
+
+// Compares two Coordinate arrays, each traversed forwards
+// or backwards according to its orientation flag.
+// Shorter arrays which are a prefix of longer ones are smaller.
+func compareOriented(pts1 []Coordinate, orientation1 bool, pts2 []Coordinate, orientation2 bool) int {
+	if len(pts1) == 0 || len(pts2) == 0 {
+		return compareInts(len(pts1), len(pts2))
+	}
+	dir1, i1, limit1 := orientedTraversal(pts1, orientation1)
+	dir2, i2, limit2 := orientedTraversal(pts2, orientation2)
+	for {
+		if comp := pts1[i1].compareTo(pts2[i2]); comp != 0 {
+			return comp
+		}
+		i1 += dir1
+		i2 += dir2
+		done1 := i1 == limit1
+		done2 := i2 == limit2
+		if done1 && !done2 {
+			return -1
+		}
+		if !done1 && done2 {
+			return 1
+		}
+		if done1 && done2 {
+			return 0
+		}
+	}
+}
+
+// Gets the step, start index and end limit for traversing an array.
+func orientedTraversal(pts []Coordinate, orientation bool) (int, int, int) {
+	if orientation {
+		return 1, 0, len(pts)
+	}
+	return -1, len(pts) - 1, -1
+}
+
+// Attaches the methods of sort.Interface to a Coordinate array,
+// sorting in the standard Coordinate ordering.
+type CoordinateSlice []Coordinate
+
+func (s CoordinateSlice) Len() int           { return len(s) }
+func (s CoordinateSlice) Less(i, j int) bool { return s[i].compareTo(s[j]) < 0 }
+func (s CoordinateSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Adapts a Coordinate array and a CoordinateComparator to sort.Interface.
+type coordinateSorter struct {
+	pts        []Coordinate
+	comparator CoordinateComparator
+}
+
+func (s coordinateSorter) Len() int           { return len(s.pts) }
+func (s coordinateSorter) Less(i, j int) bool { return s.comparator.Compare(&s.pts[i], &s.pts[j]) < 0 }
+func (s coordinateSorter) Swap(i, j int)      { s.pts[i], s.pts[j] = s.pts[j], s.pts[i] }
+
+// Adapts a list of Coordinate arrays and a CoordinateArrayComparator to sort.Interface.
+type coordinateArraySorter struct {
+	arrays     [][]Coordinate
+	comparator CoordinateArrayComparator
+}
+
+func (s coordinateArraySorter) Len() int { return len(s.arrays) }
+func (s coordinateArraySorter) Less(i, j int) bool {
+	return s.comparator.Compare(s.arrays[i], s.arrays[j]) < 0
+}
+func (s coordinateArraySorter) Swap(i, j int) { s.arrays[i], s.arrays[j] = s.arrays[j], s.arrays[i] }
+
+// Sorts a Coordinate array in place using the given comparator.
+// The sort is stable, so coordinates which compare as equal keep their order.
+func SortCoordinates(pts []Coordinate, comparator CoordinateComparator) {
+	sort.Stable(coordinateSorter{pts: pts, comparator: comparator})
+}
+
+// Sorts a list of Coordinate arrays in place using the given comparator.
+// The sort is stable, so arrays which compare as equal keep their order.
+func SortCoordinateArrays(arrays [][]Coordinate, comparator CoordinateArrayComparator) {
+	sort.Stable(coordinateArraySorter{arrays: arrays, comparator: comparator})
+}
+
+// Merges two Coordinate arrays, each already sorted by the given comparator,
+// into a new sorted array.
+// When coordinates compare as equal, those from the first array come first.
+func MergeSortedCoordinates(pts1, pts2 []Coordinate, comparator CoordinateComparator) []Coordinate {
+	result := make([]Coordinate, 0, len(pts1)+len(pts2))
+	i, j := 0, 0
+	for i < len(pts1) && j < len(pts2) {
+		if comparator.Compare(&pts2[j], &pts1[i]) < 0 {
+			result = append(result, pts2[j])
+			j++
+		} else {
+			result = append(result, pts1[i])
+			i++
+		}
+	}
+	result = append(result, pts1[i:]...)
+	return append(result, pts2[j:]...)
+}
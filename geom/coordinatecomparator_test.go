@@ -0,0 +1,98 @@
+package geom
+
+import (
+	"errors"
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestDimensionalComparator(t *testing.T) {
+	c1 := NewCoordinate(1, 2, 3)
+	c2 := NewCoordinate(1, 2, 4)
+	c3 := NewCoordinate(1, 2, math.NaN())
+	comp2D := NewDefaultDimensionalComparator()
+	assert2.Equal(t, 0, comp2D.Compare(&c1, &c2))
+	comp3D, err := NewDimensionalComparator(3)
+	assert2.Nil(t, err)
+	assert2.Equal(t, -1, comp3D.Compare(&c1, &c2))
+	assert2.Equal(t, 1, comp3D.Compare(&c2, &c1))
+	// NaN is smaller than any number
+	assert2.Equal(t, -1, comp3D.Compare(&c3, &c1))
+	assert2.Equal(t, 0, comp3D.Compare(&c3, &c3))
+	_, err = NewDimensionalComparator(4)
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	assert2.Equal(t, "Invalid argument: Only 2 or 3 dimensions may be specified: 4", err.Error())
+}
+
+func TestToleranceComparator(t *testing.T) {
+	c1 := NewXYCoordinate(1, 2)
+	c2 := NewXYCoordinate(1.05, 1.95)
+	c3 := NewXYCoordinate(1.05, 2.5)
+	comp, err := NewToleranceComparator(0.1)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, comp.Compare(&c1, &c2))
+	assert2.Equal(t, -1, comp.Compare(&c1, &c3))
+	fine, _ := NewToleranceComparator(0.01)
+	assert2.Equal(t, 1, fine.Compare(&c2, &c1))
+
+	for _, tolerance := range []float64{-0.1, math.NaN()} {
+		_, err = NewToleranceComparator(tolerance)
+		assert2.True(t, errors.Is(err, ErrInvalidArgument), tolerance)
+	}
+}
+
+func TestArrayComparators(t *testing.T) {
+	line := createLine(0, 0, 1, 1, 2, 0)
+	reversed := createLine(2, 0, 1, 1, 0, 0)
+	assert2.Equal(t, -1, ForwardComparator{}.Compare(line, reversed))
+	assert2.Equal(t, 0, BidirectionalComparator{}.Compare(line, reversed))
+	assert2.Equal(t, 0, BidirectionalComparator{}.Compare(reversed, line))
+	longer := createLine(0, 0, 1, 1, 2, 0, 3, 3)
+	assert2.Equal(t, -1, BidirectionalComparator{}.Compare(line, longer))
+	assert2.Equal(t, 1, BidirectionalComparator{}.Compare(longer, line))
+	assert2.Equal(t, -1, BidirectionalComparator{}.Compare(nil, line))
+}
+
+func TestSortCoordinates(t *testing.T) {
+	pts := createLine(2, 0, 1, 1, 1, 0, 0, 5)
+	sort.Sort(CoordinateSlice(pts))
+	assert2.True(t, equalArrays(createLine(0, 5, 1, 0, 1, 1, 2, 0), pts))
+
+	pts = []Coordinate{NewCoordinate(0, 0, 2), NewCoordinate(0, 0, 1)}
+	comp2D := NewDefaultDimensionalComparator()
+	SortCoordinates(pts, comp2D)
+	assert2.Equal(t, 2.0, pts[0].z)
+	comp3D, _ := NewDimensionalComparator(3)
+	SortCoordinates(pts, comp3D)
+	assert2.Equal(t, 1.0, pts[0].z)
+}
+
+func TestSortCoordinateArrays(t *testing.T) {
+	lines := [][]Coordinate{
+		createLine(2, 0, 1, 1),
+		createLine(1, 1, 0, 0),
+		createLine(0, 0, 1, 1),
+	}
+	SortCoordinateArrays(lines, ForwardComparator{})
+	assert2.True(t, equalArrays(createLine(0, 0, 1, 1), lines[0]))
+	assert2.True(t, equalArrays(createLine(1, 1, 0, 0), lines[1]))
+	assert2.True(t, equalArrays(createLine(2, 0, 1, 1), lines[2]))
+
+	lines = [][]Coordinate{
+		createLine(2, 0, 1, 1),
+		createLine(1, 1, 0, 0),
+		createLine(0, 0, 1, 1),
+	}
+	SortCoordinateArrays(lines, BidirectionalComparator{})
+	// reversed duplicates keep their relative order
+	assert2.True(t, equalArrays(createLine(1, 1, 0, 0), lines[0]))
+	assert2.True(t, equalArrays(createLine(0, 0, 1, 1), lines[1]))
+	assert2.True(t, equalArrays(createLine(2, 0, 1, 1), lines[2]))
+}
+
+func TestMergeSortedCoordinates(t *testing.T) {
+	pts := MergeSortedCoordinates(createLine(0, 0, 2, 0), createLine(1, 0, 2, 0, 3, 0), NewDefaultDimensionalComparator())
+	assert2.True(t, equalArrays(createLine(0, 0, 1, 0, 2, 0, 2, 0, 3, 0), pts))
+}
@@ -0,0 +1,63 @@
+package geom
+
+// An interface for classes which use the values of the coordinates in a geometry.
+// Coordinate filters can be used to implement centroid and
+// envelope computation, and many other functions.
+//
+// CoordinateFilter is
+// an example of the Gang-of-Four Visitor pattern.
+//
+// Note: a CoordinateFilter is not guaranteed to be able to
+// change the coordinates of a geometry safely.
+// Use a CoordinateSequenceFilter instead if this is required.
+type CoordinateFilter interface {
+	// Performs an operation with the provided coord.
+	Filter(coord *Coordinate)
+}
+
+// An interface for classes which process the coordinates in a coordinate array.
+// A filter can either record information about each coordinate,
+// or change the value of the coordinate.
+// Filters can be
+// used to implement operations such as coordinate transformations, centroid and
+// envelope computation, and many other functions.
+//
+// A filter can indicate that it is done processing the coordinates,
+// which ends the traversal early.
+// A filter which modifies the coordinates must report this,
+// so that any values derived from them (such as a cached Envelope)
+// can be recomputed.
+type CoordinateSequenceFilter interface {
+	// Performs an operation on a coordinate in an array.
+	Filter(seq []Coordinate, i int)
+	// Reports whether the application of this filter can be terminated.
+	// Once this method returns true, it must
+	// continue to return true on every subsequent call.
+	IsDone() bool
+	// Reports whether the execution of this filter
+	// has modified the coordinates of the array.
+	IsGeometryChanged() bool
+}
+
+// Applies a CoordinateFilter to each coordinate of an array.
+// The filter is given a reference to the coordinate in the array.
+func ApplyCoordinateFilter(pts []Coordinate, filter CoordinateFilter) {
+	for i := range pts {
+		filter.Filter(&pts[i])
+	}
+}
+
+// Applies a CoordinateSequenceFilter to the coordinates of an array,
+// in order, until the filter reports that it is done.
+// Returns whether the filter changed the coordinates;
+// callers holding a cached Envelope of the coordinates must recompute it
+// if it did.
+func ApplyCoordinateSequenceFilter(pts []Coordinate, filter CoordinateSequenceFilter) bool {
+	for i := range pts {
+		if filter.IsDone() {
+			break
+		}
+		filter.Filter(pts, i)
+	}
+	return filter.IsGeometryChanged()
+}
@@ -0,0 +1,64 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// A filter which stops at the first coordinate with a large X.
+type firstLargeXFilter struct {
+	limit float64
+	found int
+	done  bool
+	count int
+}
+
+func (f *firstLargeXFilter) Filter(seq []Coordinate, i int) {
+	f.count++
+	if seq[i].x > f.limit {
+		f.found = i
+		f.done = true
+	}
+}
+
+func (f *firstLargeXFilter) IsDone() bool {
+	return f.done
+}
+
+func (f *firstLargeXFilter) IsGeometryChanged() bool {
+	return false
+}
+
+func TestCoordinateArrayFilter(t *testing.T) {
+	pts := createLine(0, 0, 1, 1, 0, 0)
+	filter := NewCoordinateArrayFilter()
+	ApplyCoordinateFilter(pts, &filter)
+	assert2.True(t, equalArrays(pts, filter.GetCoordinates()))
+}
+
+func TestUniqueCoordinateArrayFilter(t *testing.T) {
+	pts := createLine(0, 0, 1, 1, 0, 0, 2, 2, 1, 1)
+	assert2.True(t, equalArrays(createLine(0, 0, 1, 1, 2, 2), FilterUniqueCoordinates(pts)))
+	assert2.Equal(t, 0, len(FilterUniqueCoordinates(nil)))
+}
+
+func TestEnvelopeFilter(t *testing.T) {
+	filter := NewEnvelopeFilter()
+	ApplyCoordinateFilter(createLine(3, -1, 1, 4, 2, 2), &filter)
+	assert2.Equal(t, NewEnvelope(1, 3, -1, 4), filter.GetEnvelope())
+}
+
+func TestCoordinatePrecisionReducerFilter(t *testing.T) {
+	pts := createLine(0.4, 0.6, 1.2, 1.7)
+	filter := NewCoordinatePrecisionReducerFilter(mustFixedPrecisionModel(1))
+	assert2.True(t, ApplyCoordinateSequenceFilter(pts, &filter))
+	assert2.True(t, equalArrays(createLine(0, 1, 1, 2), pts))
+}
+
+func TestCoordinateSequenceFilterDone(t *testing.T) {
+	filter := &firstLargeXFilter{limit: 1.5}
+	changed := ApplyCoordinateSequenceFilter(createLine(0, 0, 1, 0, 2, 0, 3, 0), filter)
+	assert2.False(t, changed)
+	assert2.Equal(t, 2, filter.found)
+	assert2.Equal(t, 3, filter.count)
+}
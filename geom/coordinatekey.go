@@ -0,0 +1,45 @@
+package geom
+
+import "math"
+
+// A comparable key for a Coordinate, which can be used as a map key.
+// Keys are equal exactly when the coordinates are equal in 2D,
+// using the same semantics as Coordinate.equals2D:
+// the Z ordinate is ignored, -0 is equal to 0,
+// and a coordinate with a NaN X or Y is not equal to any other.
+type CoordinateKey struct {
+	x float64
+	y float64
+}
+
+// Creates the key of a Coordinate.
+func NewCoordinateKey(c Coordinate) CoordinateKey {
+	return CoordinateKey{c.x, c.y}
+}
+
+// Gets the coordinate of the key, with no Z ordinate.
+func (k CoordinateKey) GetCoordinate() Coordinate {
+	return NewXYCoordinate(k.x, k.y)
+}
+
+// Computes a hash code for an ordinate value.
+// -0 hashes the same as 0, and all NaN values hash the same.
+func hashOrdinate(value float64) uint64 {
+	if value == 0 {
+		return 0
+	}
+	if math.IsNaN(value) {
+		return math.Float64bits(math.NaN())
+	}
+	bits := math.Float64bits(value)
+	return bits ^ (bits >> 32)
+}
+
+// Combines ordinate hash codes, in the manner of Java's hashCode methods.
+func hashOrdinates(values ...float64) uint64 {
+	result := uint64(17)
+	for _, v := range values {
+		result = 37*result + hashOrdinate(v)
+	}
+	return result
+}
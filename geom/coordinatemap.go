@@ -0,0 +1,241 @@
+package geom
+
+import "math"
+
+// An entry of a CoordinateMap.
+type coordinateMapEntry struct {
+	coord   Coordinate
+	value   interface{}
+	removed bool
+}
+
+// A map keyed by Coordinate(s), which matches keys up to a tolerance.
+// Two coordinates match if they are equal under Coordinate.equals2DWithTolerance;
+// with a tolerance of 0 they must be equal in 2D.
+// The first coordinate stored for a location is kept as the key,
+// so the map can be used to snap coordinates to a canonical representative.
+// If several stored coordinates match, the closest one is used.
+//
+// Lookups use a grid of cells the size of the tolerance,
+// so they take constant time on average.
+// Coordinates with a NaN X or Y never match any coordinate.
+type CoordinateMap struct {
+	tolerance float64
+	cells     map[CoordinateKey][]*coordinateMapEntry
+	entries   []*coordinateMapEntry
+	size      int
+}
+
+// Creates an empty map which matches coordinates within the tolerance.
+// Returns ErrInvalidArgument if the tolerance is negative or NaN.
+func NewCoordinateMap(tolerance float64) (CoordinateMap, error) {
+	if !(tolerance >= 0) {
+		return CoordinateMap{}, invalidArgumentError("Tolerance must not be negative: %g", tolerance)
+	}
+	return CoordinateMap{
+		tolerance: tolerance,
+		cells:     make(map[CoordinateKey][]*coordinateMapEntry),
+	}, nil
+}
+
+// Gets the key of the grid cell containing a coordinate.
+func (m *CoordinateMap) cellKey(c Coordinate) CoordinateKey {
+	if m.tolerance == 0 {
+		return NewCoordinateKey(c)
+	}
+	return CoordinateKey{math.Floor(c.x / m.tolerance), math.Floor(c.y / m.tolerance)}
+}
+
+// Finds the closest stored entry matching a coordinate.
+func (m *CoordinateMap) find(c Coordinate) *coordinateMapEntry {
+	if m.tolerance == 0 {
+		if cell := m.cells[m.cellKey(c)]; len(cell) > 0 {
+			return cell[0]
+		}
+		return nil
+	}
+	// a matching coordinate lies in the same or a neighbouring cell
+	cell := m.cellKey(c)
+	var best *coordinateMapEntry
+	bestDist := 0.0
+	for dx := -1.0; dx <= 1; dx++ {
+		for dy := -1.0; dy <= 1; dy++ {
+			for _, e := range m.cells[CoordinateKey{cell.x + dx, cell.y + dy}] {
+				if !c.equals2DWithTolerance(&e.coord, m.tolerance) {
+					continue
+				}
+				dist := c.distance(&e.coord)
+				if best == nil || dist < bestDist {
+					best = e
+					bestDist = dist
+				}
+			}
+		}
+	}
+	return best
+}
+
+// Associates a value with a coordinate.
+// If a stored coordinate matches, its value is replaced
+// and the stored coordinate is kept.
+// Returns the stored coordinate for the location.
+func (m *CoordinateMap) Put(c Coordinate, value interface{}) Coordinate {
+	if e := m.find(c); e != nil {
+		e.value = value
+		return e.coord
+	}
+	e := &coordinateMapEntry{coord: c, value: value}
+	key := m.cellKey(c)
+	m.cells[key] = append(m.cells[key], e)
+	m.entries = append(m.entries, e)
+	m.size++
+	return c
+}
+
+// Gets the value associated with a coordinate, if there is a match.
+func (m *CoordinateMap) Get(c Coordinate) (interface{}, bool) {
+	if e := m.find(c); e != nil {
+		return e.value, true
+	}
+	return nil, false
+}
+
+// Gets the stored coordinate matching a coordinate, if there is one.
+func (m *CoordinateMap) GetCoordinate(c Coordinate) (Coordinate, bool) {
+	if e := m.find(c); e != nil {
+		return e.coord, true
+	}
+	return Coordinate{}, false
+}
+
+// Tests whether a stored coordinate matches a coordinate.
+func (m *CoordinateMap) Contains(c Coordinate) bool {
+	return m.find(c) != nil
+}
+
+// Removes the entry matching a coordinate.
+// Returns whether an entry was removed.
+func (m *CoordinateMap) Remove(c Coordinate) bool {
+	e := m.find(c)
+	if e == nil {
+		return false
+	}
+	key := m.cellKey(e.coord)
+	cell := m.cells[key]
+	for i := range cell {
+		if cell[i] == e {
+			cell = append(cell[:i], cell[i+1:]...)
+			break
+		}
+	}
+	if len(cell) == 0 {
+		delete(m.cells, key)
+	} else {
+		m.cells[key] = cell
+	}
+	e.removed = true
+	m.size--
+	// compact the insertion order once it is mostly removed entries
+	if len(m.entries) > 2*m.size+16 {
+		m.compact()
+	}
+	return true
+}
+
+func (m *CoordinateMap) compact() {
+	live := make([]*coordinateMapEntry, 0, m.size)
+	for _, e := range m.entries {
+		if !e.removed {
+			live = append(live, e)
+		}
+	}
+	m.entries = live
+}
+
+// Gets the number of entries in the map.
+func (m *CoordinateMap) Len() int {
+	return m.size
+}
+
+// Gets the stored coordinates, in insertion order.
+func (m *CoordinateMap) Keys() []Coordinate {
+	keys := make([]Coordinate, 0, m.size)
+	for _, e := range m.entries {
+		if !e.removed {
+			keys = append(keys, e.coord)
+		}
+	}
+	return keys
+}
+
+// Gets the values, in the insertion order of their coordinates.
+func (m *CoordinateMap) Values() []interface{} {
+	values := make([]interface{}, 0, m.size)
+	for _, e := range m.entries {
+		if !e.removed {
+			values = append(values, e.value)
+		}
+	}
+	return values
+}
+
+// A set of Coordinate(s), which matches coordinates up to a tolerance
+// in the same way as CoordinateMap.
+type CoordinateSet struct {
+	coords CoordinateMap
+}
+
+// Creates an empty set which matches coordinates within the tolerance.
+// Returns ErrInvalidArgument if the tolerance is negative or NaN.
+func NewCoordinateSet(tolerance float64) (CoordinateSet, error) {
+	coords, err := NewCoordinateMap(tolerance)
+	if err != nil {
+		return CoordinateSet{}, err
+	}
+	return CoordinateSet{
+		coords: coords,
+	}, nil
+}
+
+// Adds a coordinate to the set, unless a matching coordinate is present.
+// Returns whether the coordinate was added.
+func (s *CoordinateSet) Add(c Coordinate) bool {
+	if s.coords.Contains(c) {
+		return false
+	}
+	s.coords.Put(c, nil)
+	return true
+}
+
+// Adds each coordinate of an array to the set.
+func (s *CoordinateSet) AddAll(pts []Coordinate) {
+	for _, c := range pts {
+		s.Add(c)
+	}
+}
+
+// Tests whether the set contains a coordinate matching a coordinate.
+func (s *CoordinateSet) Contains(c Coordinate) bool {
+	return s.coords.Contains(c)
+}
+
+// Gets the coordinate in the set matching a coordinate, if there is one.
+func (s *CoordinateSet) Find(c Coordinate) (Coordinate, bool) {
+	return s.coords.GetCoordinate(c)
+}
+
+// Removes the coordinate matching a coordinate.
+// Returns whether a coordinate was removed.
+func (s *CoordinateSet) Remove(c Coordinate) bool {
+	return s.coords.Remove(c)
+}
+
+// Gets the number of coordinates in the set.
+func (s *CoordinateSet) Len() int {
+	return s.coords.Len()
+}
+
+// Gets the coordinates in the set, in insertion order.
+func (s *CoordinateSet) Coordinates() []Coordinate {
+	return s.coords.Keys()
+}
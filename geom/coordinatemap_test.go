@@ -0,0 +1,134 @@
+package geom
+
+import (
+	"errors"
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestCoordinateHash(t *testing.T) {
+	a := NewCoordinate(1, 2, 3)
+	b := NewXYCoordinate(1, 2)
+	assert2.Equal(t, a.Hash(), b.Hash())
+	assert2.NotEqual(t, a.Hash3D(), b.Hash3D())
+	c := NewXYCoordinate(2, 1)
+	assert2.NotEqual(t, a.Hash(), c.Hash())
+
+	zero := NewXYCoordinate(0, 0)
+	negZero := NewXYCoordinate(math.Copysign(0, -1), 0)
+	assert2.True(t, zero.equals2D(&negZero))
+	assert2.Equal(t, zero.Hash(), negZero.Hash())
+
+	nanZ1 := NewCoordinate(1, 2, math.NaN())
+	nanZ2 := NewCoordinate(1, 2, -math.NaN())
+	assert2.True(t, nanZ1.equals3D(&nanZ2))
+	assert2.Equal(t, nanZ1.Hash3D(), nanZ2.Hash3D())
+	// hash codes are stable
+	assert2.Equal(t, uint64((17*37)*37), zero.Hash())
+}
+
+func TestEnvelopeHash(t *testing.T) {
+	env := NewEnvelope(0, 10, 0, 5)
+	assert2.True(t, env.Equals(NewEnvelope(10, 0, 5, 0)))
+	same := NewEnvelopeFromCoordinates(NewXYCoordinate(10, 5), NewXYCoordinate(0, 0))
+	assert2.Equal(t, env.Hash(), same.Hash())
+	other := NewEnvelope(0, 5, 0, 10)
+	assert2.False(t, env.Equals(other))
+	assert2.NotEqual(t, env.Hash(), other.Hash())
+
+	empty := NewEmptyEnvelope()
+	assert2.True(t, empty.Equals(Envelope{minX: 5, maxX: 4}))
+	assert2.Equal(t, empty.Hash(), (&Envelope{minX: 5, maxX: 4}).Hash())
+}
+
+func TestCoordinateKey(t *testing.T) {
+	m := map[CoordinateKey]int{}
+	m[NewCoordinateKey(NewCoordinate(1, 2, 3))] = 1
+	assert2.Equal(t, 1, m[NewCoordinateKey(NewXYCoordinate(1, 2))])
+	m[NewCoordinateKey(NewXYCoordinate(math.Copysign(0, -1), 0))] = 2
+	assert2.Equal(t, 2, m[NewCoordinateKey(NewXYCoordinate(0, 0))])
+	key := NewCoordinateKey(NewXYCoordinate(1, 2))
+	c := key.GetCoordinate()
+	assert2.Equal(t, 1.0, c.x)
+	assert2.Equal(t, 2.0, c.y)
+}
+
+func TestCoordinateMapExact(t *testing.T) {
+	m, _ := NewCoordinateMap(0)
+	m.Put(NewXYCoordinate(1, 1), "a")
+	m.Put(NewXYCoordinate(2, 2), "b")
+	m.Put(NewCoordinate(1, 1, 5), "c")
+	assert2.Equal(t, 2, m.Len())
+	v, ok := m.Get(NewXYCoordinate(1, 1))
+	assert2.True(t, ok)
+	assert2.Equal(t, "c", v)
+	_, ok = m.Get(NewXYCoordinate(1, 1.0000001))
+	assert2.False(t, ok)
+	assert2.Equal(t, []interface{}{"c", "b"}, m.Values())
+}
+
+func TestCoordinateMapTolerance(t *testing.T) {
+	m, _ := NewCoordinateMap(0.5)
+	stored := m.Put(NewXYCoordinate(1, 1), 1)
+	assert2.True(t, stored.equals2D(&Coordinate{x: 1, y: 1}))
+	// a coordinate in a neighbouring cell matches
+	stored = m.Put(NewXYCoordinate(0.9, 1.4), 2)
+	assert2.True(t, stored.equals2D(&Coordinate{x: 1, y: 1}))
+	assert2.Equal(t, 1, m.Len())
+	m.Put(NewXYCoordinate(2, 1), 3)
+	assert2.Equal(t, 2, m.Len())
+
+	// the closest coordinate is found
+	found, ok := m.GetCoordinate(NewXYCoordinate(1.6, 1))
+	assert2.True(t, ok)
+	assert2.Equal(t, 2.0, found.x)
+	_, ok = m.GetCoordinate(NewXYCoordinate(1.5, 1.6))
+	assert2.False(t, ok)
+
+	assert2.True(t, m.Remove(NewXYCoordinate(1.1, 1.1)))
+	assert2.False(t, m.Contains(NewXYCoordinate(1, 1)))
+	assert2.False(t, m.Remove(NewXYCoordinate(1, 1)))
+	assert2.Equal(t, 1, m.Len())
+	assert2.Equal(t, 1, len(m.Keys()))
+}
+
+func TestCoordinateMapNaN(t *testing.T) {
+	m, _ := NewCoordinateMap(1)
+	m.Put(NewXYCoordinate(math.NaN(), 0), 1)
+	assert2.False(t, m.Contains(NewXYCoordinate(math.NaN(), 0)))
+}
+
+func TestCoordinateSet(t *testing.T) {
+	s, _ := NewCoordinateSet(0.01)
+	s.AddAll(createLine(0, 0, 0.005, 0.005, 1, 1, 0, 0))
+	assert2.Equal(t, 2, s.Len())
+	assert2.False(t, s.Add(NewXYCoordinate(1.001, 0.999)))
+	assert2.True(t, s.Add(NewXYCoordinate(2, 2)))
+	found, ok := s.Find(NewXYCoordinate(0.001, 0))
+	assert2.True(t, ok)
+	assert2.Equal(t, 0.0, found.x)
+	assert2.True(t, s.Remove(NewXYCoordinate(2, 2)))
+	assert2.True(t, equalArrays(createLine(0, 0, 1, 1), s.Coordinates()))
+}
+
+func TestCoordinateMapManyRemovals(t *testing.T) {
+	m, _ := NewCoordinateMap(0)
+	for i := 0; i < 100; i++ {
+		m.Put(NewXYCoordinate(float64(i), 0), i)
+	}
+	for i := 0; i < 90; i++ {
+		m.Remove(NewXYCoordinate(float64(i), 0))
+	}
+	assert2.Equal(t, 10, m.Len())
+	assert2.Equal(t, 90, m.Values()[0])
+}
+
+func TestCoordinateMapInvalidTolerance(t *testing.T) {
+	for _, tolerance := range []float64{-1, math.NaN()} {
+		_, err := NewCoordinateMap(tolerance)
+		assert2.True(t, errors.Is(err, ErrInvalidArgument), tolerance)
+		_, err = NewCoordinateSet(tolerance)
+		assert2.True(t, errors.Is(err, ErrInvalidArgument), tolerance)
+	}
+}
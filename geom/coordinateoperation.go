@@ -0,0 +1,122 @@
+package geom
+
+// An operation which edits the coordinates of a line or ring.
+// The operation may modify the coordinates in place,
+// or return a new array. Returning nil or an empty array
+// removes the component.
+type CoordinateOperation interface {
+	Edit(coords []Coordinate) []Coordinate
+}
+
+// An adapter to allow the use of an ordinary function as a CoordinateOperation.
+type CoordinateOperationFunc func(coords []Coordinate) []Coordinate
+
+// Calls f(coords).
+func (f CoordinateOperationFunc) Edit(coords []Coordinate) []Coordinate {
+	return f(coords)
+}
+
+// Creates an operation which removes consecutive repeated points.
+func NewRemoveRepeatedPointsOperation() CoordinateOperation {
+	return CoordinateOperationFunc(removeRepeatedPoints)
+}
+
+// Creates an operation which reverses the order of the coordinates.
+func NewReverseOperation() CoordinateOperation {
+	return CoordinateOperationFunc(func(coords []Coordinate) []Coordinate {
+		reverse(coords)
+		return coords
+	})
+}
+
+// Creates an operation which scrolls the coordinates so that
+// the first coordinate equal to the given one (in 2D) comes first.
+// Rings remain closed.
+// Coordinates which do not contain the given coordinate are unchanged.
+func NewScrollOperation(firstCoordinate Coordinate) CoordinateOperation {
+	return CoordinateOperationFunc(func(coords []Coordinate) []Coordinate {
+		if i := indexOf(firstCoordinate, coords); i > 0 {
+			// the index was found in the array, so this cannot fail
+			_ = scrollWithRingCheck(coords, i)
+		}
+		return coords
+	})
+}
+
+// Creates an operation which rounds the coordinates
+// to a PrecisionModel.
+func NewPrecisionReducerOperation(pm PrecisionModel) CoordinateOperation {
+	return CoordinateOperationFunc(func(coords []Coordinate) []Coordinate {
+		for i := range coords {
+			pm.makePreciseCoordinate(&coords[i])
+		}
+		return coords
+	})
+}
+
+// Applies a chain of CoordinateOperation(s) to lines and rings.
+// The input coordinates are copied before the operations are applied,
+// so the operations may safely edit them in place.
+// A component is removed when an operation returns no coordinates,
+// and later operations are not applied to it.
+type CoordinateEditor struct {
+	operations []CoordinateOperation
+}
+
+// Creates an editor which applies the operations in order.
+func NewCoordinateEditor(operations ...CoordinateOperation) CoordinateEditor {
+	return CoordinateEditor{
+		operations: operations,
+	}
+}
+
+// Appends an operation to the chain.
+func (e *CoordinateEditor) Add(operation CoordinateOperation) {
+	e.operations = append(e.operations, operation)
+}
+
+// Edits a coordinate array.
+// Returns nil if the array was removed by an operation.
+func (e *CoordinateEditor) Edit(coords []Coordinate) []Coordinate {
+	edited := copyDeepCoordinateArray(coords)
+	for _, op := range e.operations {
+		edited = op.Edit(edited)
+		if len(edited) == 0 {
+			return nil
+		}
+	}
+	return edited
+}
+
+// Edits a set of lines.
+// Lines which are removed, or which have fewer than 2 points after editing,
+// are omitted from the result.
+func (e *CoordinateEditor) EditLines(lines [][]Coordinate) [][]Coordinate {
+	result := make([][]Coordinate, 0, len(lines))
+	for _, pts := range lines {
+		edited := e.Edit(pts)
+		if len(edited) < 2 {
+			continue
+		}
+		result = append(result, edited)
+	}
+	return result
+}
+
+// Edits a set of rings.
+// Rings which are removed are omitted from the result;
+// it is an error for an operation to produce an array which is not a ring.
+func (e *CoordinateEditor) EditRings(rings [][]Coordinate) ([][]Coordinate, error) {
+	result := make([][]Coordinate, 0, len(rings))
+	for _, ring := range rings {
+		edited := e.Edit(ring)
+		if len(edited) == 0 {
+			continue
+		}
+		if !isRing(edited) {
+			return nil, NewCoordinateError(ErrNotARing, edited[0], "edited coordinates "+toLineStringWKT(edited...))
+		}
+		result = append(result, edited)
+	}
+	return result, nil
+}
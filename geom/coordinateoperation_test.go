@@ -0,0 +1,61 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCoordinateEditorChain(t *testing.T) {
+	editor := NewCoordinateEditor(
+		NewPrecisionReducerOperation(mustFixedPrecisionModel(1)),
+		NewRemoveRepeatedPointsOperation(),
+		NewReverseOperation())
+	input := createLine(0, 0, 0.2, 0.1, 1.1, 0.9, 2, 2)
+	edited := editor.Edit(input)
+	assert2.True(t, equalArrays(createLine(2, 2, 1, 1, 0, 0), edited))
+	// the input is not modified
+	assert2.True(t, equalArrays(createLine(0, 0, 0.2, 0.1, 1.1, 0.9, 2, 2), input))
+}
+
+func TestCoordinateEditorScrollRings(t *testing.T) {
+	editor := NewCoordinateEditor(NewScrollOperation(NewXYCoordinate(1, 1)))
+	rings, err := editor.EditRings([][]Coordinate{
+		createLine(0, 0, 1, 0, 1, 1, 0, 1, 0, 0),
+		createLine(5, 5, 6, 5, 6, 6, 5, 5),
+	})
+	assert2.Nil(t, err)
+	assert2.True(t, equalArrays(createLine(1, 1, 0, 1, 0, 0, 1, 0, 1, 1), rings[0]))
+	assert2.True(t, equalArrays(createLine(5, 5, 6, 5, 6, 6, 5, 5), rings[1]))
+}
+
+func TestCoordinateEditorEmptyRings(t *testing.T) {
+	editor := NewCoordinateEditor()
+	rings, err := editor.EditRings([][]Coordinate{{}})
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, len(rings))
+}
+
+func TestCoordinateEditorRemovesComponents(t *testing.T) {
+	dropShort := CoordinateOperationFunc(func(coords []Coordinate) []Coordinate {
+		if lengthOfLine(coords) < 1 {
+			return nil
+		}
+		return coords
+	})
+	editor := NewCoordinateEditor(NewRemoveRepeatedPointsOperation())
+	editor.Add(dropShort)
+	lines := editor.EditLines([][]Coordinate{
+		createLine(0, 0, 0, 0),
+		createLine(0, 0, 0.5, 0),
+		createLine(0, 0, 2, 0),
+	})
+	assert2.Equal(t, 1, len(lines))
+	assert2.True(t, equalArrays(createLine(0, 0, 2, 0), lines[0]))
+}
+
+func TestCoordinateEditorInvalidRing(t *testing.T) {
+	editor := NewCoordinateEditor(NewPrecisionReducerOperation(mustFixedPrecisionModel(1)),
+		NewRemoveRepeatedPointsOperation())
+	_, err := editor.EditRings([][]Coordinate{createLine(0, 0, 0.1, 0, 0.1, 0.1, 0, 0)})
+	assert2.NotNil(t, err)
+}
@@ -0,0 +1,389 @@
+package geom
+
+import "math"
+
+const (
+	// SRID of geographic coordinates on the WGS84 datum
+	// (EPSG:4326), with X holding the longitude and Y the latitude in degrees.
+	SRID_WGS84 = 4326
+	// SRID of the spherical Web Mercator projection (EPSG:3857), in metres.
+	SRID_WEB_MERCATOR = 3857
+	// SRID of UTM zone 1 north on the WGS84 datum (EPSG:32601).
+	// Zone n north has SRID SRID_UTM_NORTH + n - 1.
+	SRID_UTM_NORTH = 32601
+	// SRID of UTM zone 1 south on the WGS84 datum (EPSG:32701).
+	// Zone n south has SRID SRID_UTM_SOUTH + n - 1.
+	SRID_UTM_SOUTH = 32701
+)
+
+const (
+	// Semi-major axis of the WGS84 ellipsoid, in metres
+	wgs84SemiMajorAxis = 6378137.0
+	// Flattening of the WGS84 ellipsoid
+	wgs84Flattening = 1 / 298.257223563
+	// Maximum latitude which can be represented in Web Mercator,
+	// making the projected world square
+	webMercatorMaxLatitude = 85.05112877980659
+	// Scale factor on the central meridian of a UTM zone
+	utmScaleFactor  = 0.9996
+	utmFalseEasting = 500000.0
+	// False northing used for the southern hemisphere
+	utmFalseNorthing = 10000000.0
+	// Maximum difference in degrees between a longitude and the central
+	// meridian of a UTM zone, beyond which the projection is meaningless
+	utmMaxLongitudeOffset = 45.0
+)
+
+// Transforms coordinates from one coordinate reference system to another.
+// The Z ordinate is copied unchanged.
+//
+// Geographic coordinates are always in (longitude, latitude) order,
+// that is X holds the longitude and Y the latitude, in degrees.
+type CoordinateTransform interface {
+	// Computes the image of a Coordinate in the target system.
+	// A Coordinate which lies outside the domain of the transformation
+	// gives a CoordinateError wrapping ErrOutOfDomain.
+	Transform(src Coordinate) (Coordinate, error)
+}
+
+// Creates the transformation between two coordinate reference systems
+// given by their SRIDs.
+// The supported systems are SRID_WGS84, SRID_WEB_MERCATOR and the
+// WGS84 UTM zones (EPSG:32601 - 32660 and EPSG:32701 - 32760).
+// Transformations between two projected systems go through SRID_WGS84.
+func NewCoordinateTransform(sourceSRID, targetSRID int) (CoordinateTransform, error) {
+	if sourceSRID == targetSRID {
+		if !isSupportedSRID(sourceSRID) {
+			return nil, invalidArgumentError("Unsupported SRID: %d", sourceSRID)
+		}
+		return identityTransform{}, nil
+	}
+	toGeographic, err := newToGeographicTransform(sourceSRID)
+	if err != nil {
+		return nil, err
+	}
+	fromGeographic, err := newFromGeographicTransform(targetSRID)
+	if err != nil {
+		return nil, err
+	}
+	if sourceSRID == SRID_WGS84 {
+		return fromGeographic, nil
+	}
+	if targetSRID == SRID_WGS84 {
+		return toGeographic, nil
+	}
+	return compositeTransform{toGeographic, fromGeographic}, nil
+}
+
+// Creates the transformation from WGS84 geographic coordinates
+// to Web Mercator.
+// Latitudes beyond the maximum latitude of Web Mercator (about 85.0511 degrees)
+// are clamped to it, so the poles map to the edges of the projected square.
+func NewWebMercatorTransform() CoordinateTransform {
+	return webMercatorTransform{}
+}
+
+// Creates the transformation from Web Mercator
+// to WGS84 geographic coordinates.
+func NewInverseWebMercatorTransform() CoordinateTransform {
+	return webMercatorTransform{inverse: true}
+}
+
+// Creates the transformation from WGS84 geographic coordinates
+// to a UTM zone, numbered from 1 to 60.
+// Longitudes more than 45 degrees away from the central meridian
+// of the zone are outside of the domain of the transformation.
+func NewUTMTransform(zone int, north bool) (CoordinateTransform, error) {
+	if zone < 1 || zone > 60 {
+		return nil, invalidArgumentError("Invalid UTM zone: %d", zone)
+	}
+	return utmTransform{zone: zone, north: north}, nil
+}
+
+// Creates the transformation from a UTM zone, numbered from 1 to 60,
+// to WGS84 geographic coordinates.
+func NewInverseUTMTransform(zone int, north bool) (CoordinateTransform, error) {
+	if zone < 1 || zone > 60 {
+		return nil, invalidArgumentError("Invalid UTM zone: %d", zone)
+	}
+	return utmTransform{zone: zone, north: north, inverse: true}, nil
+}
+
+// Computes the number of the standard UTM zone containing a longitude.
+// The exceptions around Norway and Svalbard are not taken into account.
+func UTMZone(lon float64) int {
+	zone := int(math.Floor((lon+180)/6)) + 1
+	if zone < 1 {
+		return 1
+	}
+	if zone > 60 {
+		return 60
+	}
+	return zone
+}
+
+// Computes the SRID of a WGS84 UTM zone.
+func UTMSRID(zone int, north bool) int {
+	if north {
+		return SRID_UTM_NORTH + zone - 1
+	}
+	return SRID_UTM_SOUTH + zone - 1
+}
+
+// Applies a transformation to all the Coordinate(s)
+// of the array, in place.
+// If a Coordinate cannot be transformed an error is returned
+// and the array is left partially transformed.
+func TransformCoordinates(pts []Coordinate, transform CoordinateTransform) error {
+	for i := range pts {
+		p, err := transform.Transform(pts[i])
+		if err != nil {
+			return err
+		}
+		pts[i] = p
+	}
+	return nil
+}
+
+// Computes the Envelope of the transformed region of an Envelope.
+// Since straight lines are in general not preserved by
+// a change of coordinate reference system, each edge of the Envelope is
+// densified into the given number of segments before being transformed.
+// A null Envelope is returned unchanged.
+func TransformEnvelope(env Envelope, transform CoordinateTransform, segmentsPerEdge int) (Envelope, error) {
+	result := NewEmptyEnvelope()
+	if env.isNull() {
+		return result, nil
+	}
+	if segmentsPerEdge < 1 {
+		segmentsPerEdge = 1
+	}
+	corners := []Coordinate{
+		NewXYCoordinate(env.minX, env.minY),
+		NewXYCoordinate(env.maxX, env.minY),
+		NewXYCoordinate(env.maxX, env.maxY),
+		NewXYCoordinate(env.minX, env.maxY),
+	}
+	for i, p0 := range corners {
+		seg := NewLineSegment(p0, corners[(i+1)%len(corners)])
+		for j := 0; j < segmentsPerEdge; j++ {
+			p, err := transform.Transform(seg.pointAlong(float64(j) / float64(segmentsPerEdge)))
+			if err != nil {
+				return NewEmptyEnvelope(), err
+			}
+			result.expandToIncludeCoordinate(p)
+		}
+	}
+	return result, nil
+}
+
+func isSupportedSRID(srid int) bool {
+	_, err := newToGeographicTransform(srid)
+	return err == nil
+}
+
+func newToGeographicTransform(srid int) (CoordinateTransform, error) {
+	switch {
+	case srid == SRID_WGS84:
+		return identityTransform{}, nil
+	case srid == SRID_WEB_MERCATOR:
+		return NewInverseWebMercatorTransform(), nil
+	case srid >= SRID_UTM_NORTH && srid < SRID_UTM_NORTH+60:
+		return NewInverseUTMTransform(srid-SRID_UTM_NORTH+1, true)
+	case srid >= SRID_UTM_SOUTH && srid < SRID_UTM_SOUTH+60:
+		return NewInverseUTMTransform(srid-SRID_UTM_SOUTH+1, false)
+	}
+	return nil, invalidArgumentError("Unsupported SRID: %d", srid)
+}
+
+func newFromGeographicTransform(srid int) (CoordinateTransform, error) {
+	switch {
+	case srid == SRID_WGS84:
+		return identityTransform{}, nil
+	case srid == SRID_WEB_MERCATOR:
+		return NewWebMercatorTransform(), nil
+	case srid >= SRID_UTM_NORTH && srid < SRID_UTM_NORTH+60:
+		return NewUTMTransform(srid-SRID_UTM_NORTH+1, true)
+	case srid >= SRID_UTM_SOUTH && srid < SRID_UTM_SOUTH+60:
+		return NewUTMTransform(srid-SRID_UTM_SOUTH+1, false)
+	}
+	return nil, invalidArgumentError("Unsupported SRID: %d", srid)
+}
+
+// Checks that a Coordinate holds a valid longitude and latitude.
+func checkGeographic(c Coordinate, maxLatitude float64) error {
+	if math.IsNaN(c.x) || math.IsNaN(c.y) || math.Abs(c.x) > 180 || math.Abs(c.y) > maxLatitude {
+		return NewCoordinateError(ErrOutOfDomain, c, "")
+	}
+	return nil
+}
+
+type identityTransform struct{}
+
+func (identityTransform) Transform(src Coordinate) (Coordinate, error) {
+	return src, nil
+}
+
+// Applies a sequence of transformations in order.
+type compositeTransform []CoordinateTransform
+
+func (t compositeTransform) Transform(src Coordinate) (Coordinate, error) {
+	result := src
+	for _, transform := range t {
+		var err error
+		result, err = transform.Transform(result)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// The spherical Mercator projection used by web mapping (EPSG:3857).
+type webMercatorTransform struct {
+	inverse bool
+}
+
+func (t webMercatorTransform) Transform(src Coordinate) (Coordinate, error) {
+	result := src.clone()
+	if t.inverse {
+		if math.IsNaN(src.x) || math.IsNaN(src.y) {
+			return result, NewCoordinateError(ErrOutOfDomain, src, "")
+		}
+		result.x = src.x / wgs84SemiMajorAxis * 180 / math.Pi
+		result.y = (2*math.Atan(math.Exp(src.y/wgs84SemiMajorAxis)) - math.Pi/2) * 180 / math.Pi
+		return result, nil
+	}
+	if err := checkGeographic(src, 90); err != nil {
+		return result, err
+	}
+	// the poles lie at infinity, so latitudes are clamped to the edge of the square world
+	lat := math.Max(-webMercatorMaxLatitude, math.Min(webMercatorMaxLatitude, src.y)) * math.Pi / 180
+	result.x = wgs84SemiMajorAxis * src.x * math.Pi / 180
+	result.y = wgs84SemiMajorAxis * math.Log(math.Tan(math.Pi/4+lat/2))
+	return result, nil
+}
+
+// The Universal Transverse Mercator projection on the WGS84 ellipsoid,
+// computed with the series expansion of Krüger to the third order in
+// the third flattening, which is accurate to better than a millimetre
+// within a zone.
+type utmTransform struct {
+	zone    int
+	north   bool
+	inverse bool
+}
+
+func (t utmTransform) Transform(src Coordinate) (Coordinate, error) {
+	if t.inverse {
+		return t.inverseTransform(src)
+	}
+	return t.forwardTransform(src)
+}
+
+// Computes the coefficients of the Krüger series for the WGS84 ellipsoid.
+func utmSeries() (rectifyingRadius float64, alpha, beta [3]float64) {
+	n := wgs84Flattening / (2 - wgs84Flattening)
+	n2 := n * n
+	n3 := n2 * n
+	rectifyingRadius = wgs84SemiMajorAxis / (1 + n) * (1 + n2/4 + n2*n2/64)
+	alpha = [3]float64{n/2 - 2*n2/3 + 5*n3/16, 13*n2/48 - 3*n3/5, 61 * n3 / 240}
+	beta = [3]float64{n/2 - 2*n2/3 + 37*n3/96, n2/48 + n3/15, 17 * n3 / 480}
+	return
+}
+
+// Computes the eccentricity of the WGS84 ellipsoid.
+func wgs84Eccentricity() float64 {
+	return math.Sqrt(wgs84Flattening * (2 - wgs84Flattening))
+}
+
+// Computes the tangent of the conformal latitude
+// from the tangent of the latitude.
+func conformalTan(tau, e float64) float64 {
+	sigma := math.Sinh(e * math.Atanh(e*tau/math.Sqrt(1+tau*tau)))
+	return tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+}
+
+// Computes the tangent of the latitude from the tangent of the
+// conformal latitude, using Newton's method as described by Karney (2011).
+func latitudeTan(tauPrime, e float64) float64 {
+	e2m := 1 - e*e
+	tau := tauPrime / e2m
+	for i := 0; i < 10; i++ {
+		tp := conformalTan(tau, e)
+		dtau := (tauPrime - tp) * (1 + e2m*tau*tau) /
+			(e2m * math.Sqrt(1+tp*tp) * math.Sqrt(1+tau*tau))
+		tau += dtau
+		if math.Abs(dtau) <= 1e-15*math.Max(1, math.Abs(tau)) {
+			break
+		}
+	}
+	return tau
+}
+
+func (t utmTransform) centralMeridian() float64 {
+	return float64(t.zone*6-183) * math.Pi / 180
+}
+
+func (t utmTransform) falseNorthing() float64 {
+	if t.north {
+		return 0
+	}
+	return utmFalseNorthing
+}
+
+func (t utmTransform) forwardTransform(src Coordinate) (Coordinate, error) {
+	result := src.clone()
+	if err := checkGeographic(src, 90); err != nil {
+		return result, err
+	}
+	rectifyingRadius, alpha, _ := utmSeries()
+	e := wgs84Eccentricity()
+
+	lat := src.y * math.Pi / 180
+	dLon := src.x*math.Pi/180 - t.centralMeridian()
+	// normalize the longitude difference to [-pi, pi]
+	dLon = math.Remainder(dLon, 2*math.Pi)
+	if math.Abs(dLon) > utmMaxLongitudeOffset*math.Pi/180 {
+		return result, NewCoordinateError(ErrOutOfDomain, src, "too far from the central meridian")
+	}
+	sinLat := math.Sin(lat)
+	tau := math.Sinh(math.Atanh(sinLat) - e*math.Atanh(e*sinLat))
+	xiPrime := math.Atan2(tau, math.Cos(dLon))
+	etaPrime := math.Asinh(math.Sin(dLon) / math.Hypot(tau, math.Cos(dLon)))
+
+	xi := xiPrime
+	eta := etaPrime
+	for j := 1; j <= 3; j++ {
+		a := alpha[j-1]
+		xi += a * math.Sin(2*float64(j)*xiPrime) * math.Cosh(2*float64(j)*etaPrime)
+		eta += a * math.Cos(2*float64(j)*xiPrime) * math.Sinh(2*float64(j)*etaPrime)
+	}
+	result.x = utmFalseEasting + utmScaleFactor*rectifyingRadius*eta
+	result.y = t.falseNorthing() + utmScaleFactor*rectifyingRadius*xi
+	return result, nil
+}
+
+func (t utmTransform) inverseTransform(src Coordinate) (Coordinate, error) {
+	result := src.clone()
+	if math.IsNaN(src.x) || math.IsNaN(src.y) {
+		return result, NewCoordinateError(ErrOutOfDomain, src, "")
+	}
+	rectifyingRadius, _, beta := utmSeries()
+
+	xi := (src.y - t.falseNorthing()) / (utmScaleFactor * rectifyingRadius)
+	eta := (src.x - utmFalseEasting) / (utmScaleFactor * rectifyingRadius)
+	xiPrime := xi
+	etaPrime := eta
+	for j := 1; j <= 3; j++ {
+		b := beta[j-1]
+		xiPrime -= b * math.Sin(2*float64(j)*xi) * math.Cosh(2*float64(j)*eta)
+		etaPrime -= b * math.Cos(2*float64(j)*xi) * math.Sinh(2*float64(j)*eta)
+	}
+	tauPrime := math.Sin(xiPrime) / math.Hypot(math.Sinh(etaPrime), math.Cos(xiPrime))
+	lat := math.Atan(latitudeTan(tauPrime, wgs84Eccentricity()))
+	lon := t.centralMeridian() + math.Atan2(math.Sinh(etaPrime), math.Cos(xiPrime))
+	result.x = math.Remainder(lon, 2*math.Pi) * 180 / math.Pi
+	result.y = lat * 180 / math.Pi
+	return result, nil
+}
@@ -0,0 +1,171 @@
+package geom
+
+import (
+	"errors"
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestWebMercator(t *testing.T) {
+	transform := NewWebMercatorTransform()
+	checkCoordinateTransform(transform, 0, 0, 0, 0, 1e-9, t)
+	checkCoordinateTransform(transform, 180, 0, 20037508.342789244, 0, 1e-6, t)
+	checkCoordinateTransform(transform, -180, webMercatorMaxLatitude, -20037508.342789244, 20037508.342789244, 1e-6, t)
+	checkCoordinateTransform(transform, 13.377704, 52.516275, 1489199.0, 6894018.0, 1, t)
+}
+
+func TestWebMercatorClampsLatitude(t *testing.T) {
+	transform := NewWebMercatorTransform()
+	checkCoordinateTransform(transform, 0, 89, 0, 20037508.342789244, 1e-6, t)
+	checkCoordinateTransform(transform, 10, -90, 1113194.9079327357, -20037508.342789244, 1e-6, t)
+}
+
+func TestWebMercatorOutsideDomain(t *testing.T) {
+	transform := NewWebMercatorTransform()
+	_, err := transform.Transform(NewXYCoordinate(0, 90.5))
+	assert2.True(t, errors.Is(err, ErrOutOfDomain))
+	_, err = transform.Transform(NewXYCoordinate(181, 0))
+	var coordErr *CoordinateError
+	assert2.True(t, errors.As(err, &coordErr))
+	assert2.True(t, coordErr.Pt.equals2D(&Coordinate{x: 181, y: 0}))
+	assert2.Equal(t, "Coordinate outside of the transformation domain at (181, 0, NaN)", err.Error())
+	_, err = transform.Transform(NewXYCoordinate(math.NaN(), 0))
+	assert2.True(t, errors.Is(err, ErrOutOfDomain))
+	_, err = NewInverseWebMercatorTransform().Transform(NewXYCoordinate(0, math.NaN()))
+	assert2.True(t, errors.Is(err, ErrOutOfDomain))
+}
+
+func TestWebMercatorRoundTrip(t *testing.T) {
+	checkRoundTrip(NewWebMercatorTransform(), NewInverseWebMercatorTransform(),
+		createLine(0, 0, 7.5, 45.2, -111.3, -33.3, 179, 80, 6.01, 12), t)
+}
+
+func TestUTM(t *testing.T) {
+	transform, err := NewUTMTransform(31, true)
+	assert2.Nil(t, err)
+	checkCoordinateTransform(transform, 3, 0, 500000, 0, 1e-6, t)
+	checkCoordinateTransform(transform, 0, 0, 166021.4431, 0, 1e-3, t)
+
+	south, err := NewUTMTransform(23, false)
+	assert2.Nil(t, err)
+	checkCoordinateTransform(south, -45, 0, 500000, 10000000, 1e-6, t)
+}
+
+func TestUTMRoundTrip(t *testing.T) {
+	forward, _ := NewUTMTransform(32, true)
+	inverse, _ := NewInverseUTMTransform(32, true)
+	checkRoundTrip(forward, inverse, createLine(9, 0, 7.5, 45.2, 11.3, -33.3, 10, 80, 6.01, 12), t)
+	forward, _ = NewUTMTransform(56, false)
+	inverse, _ = NewInverseUTMTransform(56, false)
+	checkRoundTrip(forward, inverse, createLine(153, 0, 150.5, -45.2, 155.3, -33.3, 154, -80, 150.01, 12), t)
+}
+
+func TestUTMOutsideDomain(t *testing.T) {
+	transform, _ := NewUTMTransform(31, true)
+	for _, c := range createLine(93, 0, 120, 10, -42.1, 30) {
+		_, err := transform.Transform(c)
+		assert2.True(t, errors.Is(err, ErrOutOfDomain), c)
+	}
+	// the limit applies across the antimeridian
+	transform, _ = NewUTMTransform(1, true)
+	_, err := transform.Transform(NewXYCoordinate(170, 0))
+	assert2.Nil(t, err)
+	_, err = transform.Transform(NewXYCoordinate(120, 0))
+	assert2.True(t, errors.Is(err, ErrOutOfDomain))
+}
+
+func TestInvalidUTMZone(t *testing.T) {
+	_, err := NewUTMTransform(0, true)
+	assert2.NotNil(t, err)
+	_, err = NewInverseUTMTransform(61, true)
+	assert2.NotNil(t, err)
+}
+
+func TestUTMZone(t *testing.T) {
+	assert := assert2.New(t)
+	assert.Equal(1, UTMZone(-180))
+	assert.Equal(31, UTMZone(0))
+	assert.Equal(31, UTMZone(5.99))
+	assert.Equal(60, UTMZone(180))
+	assert.Equal(32632, UTMSRID(32, true))
+	assert.Equal(32756, UTMSRID(56, false))
+}
+
+func TestNewCoordinateTransform(t *testing.T) {
+	assert := assert2.New(t)
+	transform, err := NewCoordinateTransform(SRID_WGS84, SRID_WEB_MERCATOR)
+	assert.Nil(err)
+	checkCoordinateTransform(transform, 180, 0, 20037508.342789244, 0, 1e-6, t)
+
+	transform, err = NewCoordinateTransform(SRID_WEB_MERCATOR, UTMSRID(31, true))
+	assert.Nil(err)
+	checkCoordinateTransform(transform, 3*wgs84SemiMajorAxis*math.Pi/180, 0, 500000, 0, 1e-6, t)
+
+	transform, err = NewCoordinateTransform(SRID_WGS84, SRID_WGS84)
+	assert.Nil(err)
+	checkCoordinateTransform(transform, 1, 2, 1, 2, 0, t)
+
+	_, err = NewCoordinateTransform(SRID_WGS84, 27700)
+	assert.NotNil(err)
+	_, err = NewCoordinateTransform(27700, 27700)
+	assert.NotNil(err)
+}
+
+func TestTransformCoordinateArray(t *testing.T) {
+	pts := []Coordinate{NewCoordinate(0, 0, 5), NewCoordinate(180, 0, 7)}
+	err := TransformCoordinates(pts, NewWebMercatorTransform())
+	assert2.Nil(t, err)
+	assert2.InDelta(t, 20037508.342789244, pts[1].x, 1e-6)
+	assert2.Equal(t, 7.0, pts[1].z)
+	err = TransformCoordinates(createLine(0, 0, 0, 91), NewWebMercatorTransform())
+	assert2.True(t, errors.Is(err, ErrOutOfDomain))
+}
+
+func TestTransformEnvelopeDensified(t *testing.T) {
+	assert := assert2.New(t)
+	transform, _ := NewUTMTransform(31, true)
+	env := NewEnvelope(0, 6, 40, 50)
+	corners, err := TransformEnvelope(env, transform, 1)
+	assert.Nil(err)
+	densified, err := TransformEnvelope(env, transform, 10)
+	assert.Nil(err)
+	// the projected parallels are curved, so the densified envelope is larger
+	assert.True(densified.coversEnvelope(corners))
+	assert.True(densified.minY < corners.minY)
+
+	utm, _ := NewInverseUTMTransform(31, true)
+	_, err = TransformEnvelope(NewEnvelope(0, math.NaN(), 0, 1), utm, 1)
+	assert.True(errors.Is(err, ErrOutOfDomain))
+
+	empty, err := TransformEnvelope(NewEmptyEnvelope(), transform, 10)
+	assert.Nil(err)
+	assert.True(empty.isNull())
+}
+
+func TestTransformWorldEnvelopeToWebMercator(t *testing.T) {
+	world, err := TransformEnvelope(NewEnvelope(-180, 180, -90, 90), NewWebMercatorTransform(), 4)
+	assert2.Nil(t, err)
+	const max = 20037508.342789244
+	assert2.InDelta(t, -max, world.minX, 1e-6)
+	assert2.InDelta(t, max, world.maxX, 1e-6)
+	assert2.InDelta(t, -max, world.minY, 1e-6)
+	assert2.InDelta(t, max, world.maxY, 1e-6)
+}
+
+func checkCoordinateTransform(transform CoordinateTransform, x, y, xp, yp, tolerance float64, t *testing.T) {
+	p, err := transform.Transform(NewXYCoordinate(x, y))
+	assert2.Nil(t, err)
+	assert2.InDelta(t, xp, p.x, tolerance, "unexpected x-ordinate for (%g, %g)", x, y)
+	assert2.InDelta(t, yp, p.y, tolerance, "unexpected y-ordinate for (%g, %g)", x, y)
+}
+
+func checkRoundTrip(forward, inverse CoordinateTransform, pts []Coordinate, t *testing.T) {
+	for _, p := range pts {
+		projected, err := forward.Transform(p)
+		assert2.Nil(t, err)
+		actual, err := inverse.Transform(projected)
+		assert2.Nil(t, err)
+		assert2.True(t, p.equals2DWithTolerance(&actual, 1e-9), "expected %v, got %v", p, actual)
+	}
+}
@@ -0,0 +1,305 @@
+package geom
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A one-dimensional geometry made of straight segments and circular arcs,
+// such as a LinearCurve, a CircularString or a CompoundCurve.
+// A curve is defined by its control points and can be converted to
+// an ordinary line by linearizing it to a given tolerance.
+type Curve interface {
+	// Gets the control points of the curve.
+	GetCoordinates() []Coordinate
+	// Tests whether the curve has no points.
+	IsEmpty() bool
+	// Tests whether the start and end points of the curve are equal.
+	IsClosed() bool
+	// Computes the exact envelope of the curve.
+	GetEnvelope() Envelope
+	// Computes a line approximating the curve, whose distance
+	// from the curve is no greater than the tolerance.
+	// Returns ErrInvalidArgument if the tolerance is not positive,
+	// or too small relative to the radius of an arc.
+	Linearize(tolerance float64) ([]Coordinate, error)
+	// Generates the WKT for the curve.
+	String() string
+	// Generates the ISO WKB for the curve, in little-endian byte order.
+	ToWKB() []byte
+
+	// Generates the WKT for the curve as a component of another curve.
+	componentWKT() string
+	writeWKB(buf *bytes.Buffer)
+}
+
+// Checks that a tolerance for linearization is positive.
+func checkLinearizeTolerance(tolerance float64) error {
+	if !(tolerance > 0) {
+		return invalidArgumentError("Linearization tolerance must be positive: %g", tolerance)
+	}
+	return nil
+}
+
+// Tests whether the first and last points of an array are equal.
+func isClosedArray(pts []Coordinate) bool {
+	if len(pts) == 0 {
+		return false
+	}
+	return pts[0].equals2D(&pts[len(pts)-1])
+}
+
+// Generates the ISO WKB for a curve.
+func curveToWKB(c Curve) []byte {
+	var buf bytes.Buffer
+	c.writeWKB(&buf)
+	return buf.Bytes()
+}
+
+// A curve made of straight segments only, corresponding to a LINESTRING.
+type LinearCurve struct {
+	pts []Coordinate
+}
+
+// Creates a LinearCurve from its vertices, which must be empty
+// or contain at least 2 points.
+func NewLinearCurve(pts []Coordinate) (LinearCurve, error) {
+	if len(pts) == 1 {
+		return LinearCurve{}, invalidArgumentError("Invalid number of points in LinearCurve (found 1 - must be 0 or >= 2)")
+	}
+	return LinearCurve{pts: copyDeepCoordinateArray(pts)}, nil
+}
+
+func (l LinearCurve) GetCoordinates() []Coordinate {
+	return l.pts
+}
+
+func (l LinearCurve) IsEmpty() bool {
+	return len(l.pts) == 0
+}
+
+func (l LinearCurve) IsClosed() bool {
+	return isClosedArray(l.pts)
+}
+
+func (l LinearCurve) GetEnvelope() Envelope {
+	return envelope(l.pts)
+}
+
+// Gets a copy of the vertices; a linear curve needs no approximation.
+func (l LinearCurve) Linearize(tolerance float64) ([]Coordinate, error) {
+	if err := checkLinearizeTolerance(tolerance); err != nil {
+		return nil, err
+	}
+	return copyDeepCoordinateArray(l.pts), nil
+}
+
+func (l LinearCurve) String() string {
+	return toLineStringWKT(l.pts...)
+}
+
+func (l LinearCurve) ToWKB() []byte {
+	return curveToWKB(l)
+}
+
+// Linear components are written as bare coordinate lists.
+func (l LinearCurve) componentWKT() string {
+	return toCoordinateListWKT(l.pts)
+}
+
+func (l LinearCurve) writeWKB(buf *bytes.Buffer) {
+	writeWKBHeader(buf, wkbLineString)
+	writeWKBPoints(buf, l.pts)
+}
+
+// A curve made of a sequence of circular arcs, corresponding to a CIRCULARSTRING.
+// Each arc is defined by three points: its start point, a point on the arc
+// and its end point, which is also the start point of the next arc.
+// An arc whose start and end points are equal is a full circle,
+// and an arc whose points are collinear is a pair of straight segments.
+type CircularString struct {
+	pts []Coordinate
+}
+
+// Creates a CircularString from its control points, which must be empty
+// or an odd number of at least 3 points.
+func NewCircularString(pts []Coordinate) (CircularString, error) {
+	if len(pts) != 0 && (len(pts) < 3 || len(pts)%2 == 0) {
+		return CircularString{}, invalidArgumentError("Invalid number of points in CircularString (found %d - must be 0 or an odd number >= 3)", len(pts))
+	}
+	return CircularString{pts: copyDeepCoordinateArray(pts)}, nil
+}
+
+// Gets the arcs of the circular string.
+func (s CircularString) arcs() []circularArc {
+	var arcs []circularArc
+	for i := 0; i+2 < len(s.pts); i += 2 {
+		arcs = append(arcs, circularArc{s.pts[i], s.pts[i+1], s.pts[i+2]})
+	}
+	return arcs
+}
+
+func (s CircularString) GetCoordinates() []Coordinate {
+	return s.pts
+}
+
+func (s CircularString) IsEmpty() bool {
+	return len(s.pts) == 0
+}
+
+func (s CircularString) IsClosed() bool {
+	return isClosedArray(s.pts)
+}
+
+// Computes the envelope of the arcs, which may extend beyond the control points.
+func (s CircularString) GetEnvelope() Envelope {
+	env := NewEmptyEnvelope()
+	for _, arc := range s.arcs() {
+		env.expandToIncludeEnvelope(arc.envelope())
+	}
+	return env
+}
+
+func (s CircularString) Linearize(tolerance float64) ([]Coordinate, error) {
+	if err := checkLinearizeTolerance(tolerance); err != nil {
+		return nil, err
+	}
+	result := make([]Coordinate, 0)
+	for _, arc := range s.arcs() {
+		pts, err := arc.linearize(tolerance)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pts...)
+	}
+	return removeRepeatedPoints(result), nil
+}
+
+func (s CircularString) String() string {
+	if s.IsEmpty() {
+		return "CIRCULARSTRING EMPTY"
+	}
+	return "CIRCULARSTRING " + toCoordinateListWKT(s.pts)
+}
+
+func (s CircularString) ToWKB() []byte {
+	return curveToWKB(s)
+}
+
+func (s CircularString) componentWKT() string {
+	return s.String()
+}
+
+func (s CircularString) writeWKB(buf *bytes.Buffer) {
+	writeWKBHeader(buf, wkbCircularString)
+	writeWKBPoints(buf, s.pts)
+}
+
+// A curve made of a sequence of connected LinearCurve(s) and CircularString(s),
+// corresponding to a COMPOUNDCURVE.
+type CompoundCurve struct {
+	components []Curve
+}
+
+// Creates a CompoundCurve from its components, which must be non-empty
+// LinearCurve(s) or CircularString(s), each starting at the end of the previous one.
+func NewCompoundCurve(components ...Curve) (CompoundCurve, error) {
+	for i, c := range components {
+		switch c.(type) {
+		case LinearCurve, CircularString:
+		default:
+			return CompoundCurve{}, invalidArgumentError("CompoundCurve components must be LinearCurves or CircularStrings")
+		}
+		if c.IsEmpty() {
+			return CompoundCurve{}, fmt.Errorf("%w: CompoundCurve components must not be empty", ErrEmpty)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := components[i-1].GetCoordinates()
+		end := prev[len(prev)-1]
+		start := c.GetCoordinates()[0]
+		if !end.equals2D(&start) {
+			return CompoundCurve{}, NewCoordinateError(ErrInvalidArgument, start, "CompoundCurve components are not connected")
+		}
+	}
+	return CompoundCurve{components: append([]Curve(nil), components...)}, nil
+}
+
+// Gets the components of the compound curve.
+func (c CompoundCurve) GetComponents() []Curve {
+	return c.components
+}
+
+// Gets the control points of the components,
+// without repeating the points where they join.
+func (c CompoundCurve) GetCoordinates() []Coordinate {
+	result := make([]Coordinate, 0)
+	for i, comp := range c.components {
+		pts := comp.GetCoordinates()
+		if i > 0 {
+			pts = pts[1:]
+		}
+		result = append(result, pts...)
+	}
+	return result
+}
+
+func (c CompoundCurve) IsEmpty() bool {
+	return len(c.components) == 0
+}
+
+func (c CompoundCurve) IsClosed() bool {
+	return isClosedArray(c.GetCoordinates())
+}
+
+func (c CompoundCurve) GetEnvelope() Envelope {
+	env := NewEmptyEnvelope()
+	for _, comp := range c.components {
+		env.expandToIncludeEnvelope(comp.GetEnvelope())
+	}
+	return env
+}
+
+func (c CompoundCurve) Linearize(tolerance float64) ([]Coordinate, error) {
+	if err := checkLinearizeTolerance(tolerance); err != nil {
+		return nil, err
+	}
+	result := make([]Coordinate, 0)
+	for _, comp := range c.components {
+		pts, err := comp.Linearize(tolerance)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pts...)
+	}
+	return removeRepeatedPoints(result), nil
+}
+
+func (c CompoundCurve) String() string {
+	if c.IsEmpty() {
+		return "COMPOUNDCURVE EMPTY"
+	}
+	texts := make([]string, len(c.components))
+	for i, comp := range c.components {
+		texts[i] = comp.componentWKT()
+	}
+	return "COMPOUNDCURVE ( " + strings.Join(texts, ", ") + " )"
+}
+
+func (c CompoundCurve) ToWKB() []byte {
+	return curveToWKB(c)
+}
+
+func (c CompoundCurve) componentWKT() string {
+	return c.String()
+}
+
+func (c CompoundCurve) writeWKB(buf *bytes.Buffer) {
+	writeWKBHeader(buf, wkbCompoundCurve)
+	writeWKBUint32(buf, uint32(len(c.components)))
+	for _, comp := range c.components {
+		comp.writeWKB(buf)
+	}
+}
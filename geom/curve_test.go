@@ -0,0 +1,173 @@
+package geom
+
+import (
+	"encoding/hex"
+	"errors"
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestCircularStringEnvelope(t *testing.T) {
+	// a counter-clockwise arc passing through the maximum x and y of its circle
+	cs, err := NewCircularString(createLine(0.6, -0.8, 0.8, 0.6, -0.6, 0.8))
+	assert2.Nil(t, err)
+	checkEnvelope(t, NewEnvelope(-0.6, 1, -0.8, 1), cs.GetEnvelope())
+
+	// a clockwise semicircle
+	cs, _ = NewCircularString(createLine(-1, 0, 0, 1, 1, 0))
+	checkEnvelope(t, NewEnvelope(-1, 1, 0, 1), cs.GetEnvelope())
+
+	// a full circle
+	cs, _ = NewCircularString(createLine(0, 0, 2, 0, 0, 0))
+	checkEnvelope(t, NewEnvelope(0, 2, -1, 1), cs.GetEnvelope())
+
+	// collinear points form straight segments
+	cs, _ = NewCircularString(createLine(0, 0, 3, 0, 2, 0))
+	checkEnvelope(t, NewEnvelope(0, 3, 0, 0), cs.GetEnvelope())
+}
+
+func TestCircularStringLinearize(t *testing.T) {
+	cs, _ := NewCircularString(createLine(0, 0, 2, 0, 0, 0))
+	tolerance := 0.01
+	pts, err := cs.Linearize(tolerance)
+	assert2.Nil(t, err)
+	assert2.True(t, isRing(pts))
+	assert2.True(t, pts[0].equals2D(&Coordinate{x: 0, y: 0}))
+	centre := NewXYCoordinate(1, 0)
+	for i := 1; i < len(pts); i++ {
+		assert2.InDelta(t, 1.0, pts[i].distance(&centre), 1e-9)
+		seg := NewLineSegment(pts[i-1], pts[i])
+		mid := seg.midPoint()
+		assert2.True(t, 1-mid.distance(&centre) <= tolerance)
+	}
+	assert2.InDelta(t, math.Pi, signedRingArea(pts), 0.05)
+
+	// a coarser tolerance gives fewer points, but keeps the shape
+	coarse, _ := cs.Linearize(10)
+	assert2.Equal(t, 5, len(coarse))
+	assert2.True(t, len(coarse) < len(pts))
+
+	// two arcs join without repeated points
+	cs, _ = NewCircularString(createLine(0, 0, 1, 1, 2, 0, 3, -1, 4, 0))
+	pts, _ = cs.Linearize(0.1)
+	assert2.False(t, hasRepeatedPoints(pts))
+	joins := 0
+	for _, p := range pts {
+		if p.equals2D(&Coordinate{x: 2, y: 0}) {
+			joins++
+		}
+	}
+	assert2.Equal(t, 1, joins)
+	assert2.True(t, pts[len(pts)-1].equals2D(&Coordinate{x: 4, y: 0}))
+
+	_, err = cs.Linearize(0)
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func TestCircularStringLinearizeTinyTolerance(t *testing.T) {
+	cs, err := NewCircularString(createLine(0, 0, 1, 1, 2, 0))
+	assert2.Nil(t, err)
+	for _, tolerance := range []float64{1e-17, 1e-12} {
+		_, err = cs.Linearize(tolerance)
+		assert2.True(t, errors.Is(err, ErrInvalidArgument), tolerance)
+	}
+	pts, err := cs.Linearize(1e-9)
+	assert2.Nil(t, err)
+	assert2.True(t, len(pts) <= maxArcSegments+1)
+}
+
+func TestCircularStringInvalid(t *testing.T) {
+	_, err := NewCircularString(createLine(0, 0, 1, 1))
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = NewCircularString(createLine(0, 0, 1, 1, 2, 0, 3, 3))
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	cs, err := NewCircularString(nil)
+	assert2.Nil(t, err)
+	assert2.True(t, cs.IsEmpty())
+	assert2.Equal(t, "CIRCULARSTRING EMPTY", cs.String())
+	env := cs.GetEnvelope()
+	assert2.True(t, env.isNull())
+}
+
+func TestCompoundCurve(t *testing.T) {
+	line, _ := NewLinearCurve(createLine(0, 0, 2, 0))
+	arc, _ := NewCircularString(createLine(2, 0, 3, 1, 4, 0))
+	closing, _ := NewLinearCurve(createLine(4, 0, 4, -1, 0, -1, 0, 0))
+	cc, err := NewCompoundCurve(line, arc, closing)
+	assert2.Nil(t, err)
+	assert2.True(t, cc.IsClosed())
+	assert2.True(t, equalArrays(createLine(0, 0, 2, 0, 3, 1, 4, 0, 4, -1, 0, -1, 0, 0), cc.GetCoordinates()))
+	checkEnvelope(t, NewEnvelope(0, 4, -1, 1), cc.GetEnvelope())
+	assert2.Equal(t, "COMPOUNDCURVE ( ( 0 0, 2 0 ), CIRCULARSTRING ( 2 0, 3 1, 4 0 ), ( 4 0, 4 -1, 0 -1, 0 0 ) )", cc.String())
+
+	pts, err := cc.Linearize(0.01)
+	assert2.Nil(t, err)
+	assert2.True(t, isRing(pts))
+	assert2.False(t, hasRepeatedPoints(pts))
+	assert2.InDelta(t, -(4 + math.Pi/2), signedRingArea(pts), 0.02)
+
+	_, err = NewCompoundCurve(line, closing)
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	var coordErr *CoordinateError
+	assert2.True(t, errors.As(err, &coordErr))
+	empty, _ := NewLinearCurve(nil)
+	_, err = NewCompoundCurve(line, empty)
+	assert2.True(t, errors.Is(err, ErrEmpty))
+	_, err = NewCompoundCurve(cc)
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = NewLinearCurve(createLine(0, 0))
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func TestCurvePolygon(t *testing.T) {
+	shell, _ := NewCircularString(createLine(-2, 0, 2, 0, -2, 0))
+	hole, _ := NewLinearCurve(createLine(-1, -1, -1, 1, 1, 1, 1, -1, -1, -1))
+	polygon, err := NewCurvePolygon(shell, hole)
+	assert2.Nil(t, err)
+	checkEnvelope(t, NewEnvelope(-2, 2, -2, 2), polygon.GetEnvelope())
+	assert2.Equal(t, "CURVEPOLYGON ( CIRCULARSTRING ( -2 0, 2 0, -2 0 ), ( -1 -1, -1 1, 1 1, 1 -1, -1 -1 ) )", polygon.String())
+
+	rings, err := polygon.Linearize(0.001)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 2, len(rings))
+	assert2.InDelta(t, 4*math.Pi, signedRingArea(rings[0]), 0.01)
+	assert2.Equal(t, 5, len(rings[1]))
+
+	open, _ := NewCircularString(createLine(0, 0, 1, 1, 2, 0))
+	_, err = NewCurvePolygon(open)
+	assert2.True(t, errors.Is(err, ErrNotARing))
+	_, err = NewCurvePolygon(shell, open)
+	assert2.True(t, errors.Is(err, ErrNotARing))
+
+	empty, _ := NewCurvePolygon(nil)
+	assert2.Equal(t, "CURVEPOLYGON EMPTY", empty.String())
+	assert2.Equal(t, "010a00000000000000", hex.EncodeToString(empty.ToWKB()))
+}
+
+func TestCurveWKB(t *testing.T) {
+	cs, _ := NewCircularString(createLine(0, 0, 1, 1, 2, 0))
+	assert2.Equal(t, "0108000000"+"03000000"+
+		"0000000000000000"+"0000000000000000"+
+		"000000000000f03f"+"000000000000f03f"+
+		"0000000000000040"+"0000000000000000",
+		hex.EncodeToString(cs.ToWKB()))
+
+	line, _ := NewLinearCurve(createLine(2, 0, 0, 0))
+	cc, _ := NewCompoundCurve(cs, line)
+	wkb := cc.ToWKB()
+	assert2.Equal(t, "010900000002000000", hex.EncodeToString(wkb[:9]))
+	assert2.Equal(t, cs.ToWKB(), wkb[9:9+len(cs.ToWKB())])
+	assert2.Equal(t, "0102000000", hex.EncodeToString(wkb[9+len(cs.ToWKB()):14+len(cs.ToWKB())]))
+
+	polygon, _ := NewCurvePolygon(cc)
+	assert2.Equal(t, "010a00000001000000", hex.EncodeToString(polygon.ToWKB()[:9]))
+	assert2.Equal(t, wkb, polygon.ToWKB()[9:])
+}
+
+func checkEnvelope(t *testing.T, expected, actual Envelope) {
+	assert2.InDelta(t, expected.minX, actual.minX, 1e-9)
+	assert2.InDelta(t, expected.maxX, actual.maxX, 1e-9)
+	assert2.InDelta(t, expected.minY, actual.minY, 1e-9)
+	assert2.InDelta(t, expected.maxY, actual.maxY, 1e-9)
+}
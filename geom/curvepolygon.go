@@ -0,0 +1,118 @@
+package geom
+
+import (
+	"bytes"
+	"strings"
+)
+
+// A polygon whose rings are closed curves, corresponding to a CURVEPOLYGON.
+// The rings may be LinearCurve(s), CircularString(s) or CompoundCurve(s).
+type CurvePolygon struct {
+	shell Curve
+	holes []Curve
+}
+
+// Creates a CurvePolygon from its shell and holes.
+// Each ring must be closed, or the error wraps ErrNotARing.
+// A nil shell creates an empty polygon.
+func NewCurvePolygon(shell Curve, holes ...Curve) (CurvePolygon, error) {
+	if shell == nil {
+		return CurvePolygon{}, nil
+	}
+	for _, ring := range append([]Curve{shell}, holes...) {
+		if err := checkCurveRing(ring); err != nil {
+			return CurvePolygon{}, err
+		}
+	}
+	return CurvePolygon{
+		shell: shell,
+		holes: append([]Curve(nil), holes...),
+	}, nil
+}
+
+// Checks that a curve is a valid ring.
+func checkCurveRing(ring Curve) error {
+	pts := ring.GetCoordinates()
+	if len(pts) == 0 {
+		return NewCoordinateError(ErrNotARing, NewEmptyCoordinate(), "curve polygon ring is empty")
+	}
+	if _, isLinear := ring.(LinearCurve); isLinear && !isRing(pts) {
+		return NewCoordinateError(ErrNotARing, pts[0], "curve polygon ring "+toLineStringWKT(pts...))
+	}
+	if !ring.IsClosed() {
+		return NewCoordinateError(ErrNotARing, pts[0], "curve polygon ring is not closed")
+	}
+	return nil
+}
+
+// Gets the shell of the polygon, which is nil for an empty polygon.
+func (p CurvePolygon) GetShell() Curve {
+	return p.shell
+}
+
+// Gets the holes of the polygon.
+func (p CurvePolygon) GetHoles() []Curve {
+	return p.holes
+}
+
+// Tests whether the polygon has no rings.
+func (p CurvePolygon) IsEmpty() bool {
+	return p.shell == nil
+}
+
+// Computes the exact envelope of the polygon, which is the envelope of its shell.
+func (p CurvePolygon) GetEnvelope() Envelope {
+	if p.IsEmpty() {
+		return NewEmptyEnvelope()
+	}
+	return p.shell.GetEnvelope()
+}
+
+// Computes a polygon approximating this one, given as a list of rings
+// with the shell first, whose rings are no further than the tolerance
+// from the curved rings.
+func (p CurvePolygon) Linearize(tolerance float64) ([][]Coordinate, error) {
+	if err := checkLinearizeTolerance(tolerance); err != nil {
+		return nil, err
+	}
+	if p.IsEmpty() {
+		return [][]Coordinate{}, nil
+	}
+	result := make([][]Coordinate, 0, len(p.holes)+1)
+	for _, ring := range append([]Curve{p.shell}, p.holes...) {
+		pts, err := ring.Linearize(tolerance)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pts)
+	}
+	return result, nil
+}
+
+// Generates the WKT for the polygon.
+func (p CurvePolygon) String() string {
+	if p.IsEmpty() {
+		return "CURVEPOLYGON EMPTY"
+	}
+	texts := []string{p.shell.componentWKT()}
+	for _, hole := range p.holes {
+		texts = append(texts, hole.componentWKT())
+	}
+	return "CURVEPOLYGON ( " + strings.Join(texts, ", ") + " )"
+}
+
+// Generates the ISO WKB for the polygon, in little-endian byte order.
+func (p CurvePolygon) ToWKB() []byte {
+	var buf bytes.Buffer
+	writeWKBHeader(&buf, wkbCurvePolygon)
+	if p.IsEmpty() {
+		writeWKBUint32(&buf, 0)
+		return buf.Bytes()
+	}
+	writeWKBUint32(&buf, uint32(len(p.holes)+1))
+	p.shell.writeWKB(&buf)
+	for _, hole := range p.holes {
+		hole.writeWKB(&buf)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,90 @@
+package geom
+
+import "sort"
+
+// A utility class which creates Delaunay Triangulations
+// from collections of points and extract the resulting
+// triangulation edges or triangles as Coordinate arrays.
+//
+// Sites closer together than the tolerance are merged,
+// using Coordinate.equals2DWithTolerance.
+type DelaunayTriangulationBuilder struct {
+	siteCoords []Coordinate
+	tolerance  float64
+	subdiv     *QuadEdgeSubdivision
+}
+
+// Creates a new triangulation builder.
+func NewDelaunayTriangulationBuilder() DelaunayTriangulationBuilder {
+	return DelaunayTriangulationBuilder{}
+}
+
+// Sets the sites (vertices) which will be triangulated.
+// Repeated sites (up to the tolerance) are triangulated only once.
+func (b *DelaunayTriangulationBuilder) SetSites(coords []Coordinate) {
+	b.siteCoords = copyDeepCoordinateArray(coords)
+	b.subdiv = nil
+}
+
+// Sets the snapping tolerance which will be used
+// to improved the robustness of the triangulation computation.
+// A tolerance of 0.0 specifies that no snapping will take place.
+func (b *DelaunayTriangulationBuilder) SetTolerance(tolerance float64) {
+	b.tolerance = tolerance
+	b.subdiv = nil
+}
+
+func (b *DelaunayTriangulationBuilder) create() error {
+	if b.subdiv != nil {
+		return nil
+	}
+	sites := uniqueCoordinates(b.siteCoords, b.tolerance)
+	subdiv := NewQuadEdgeSubdivision(envelope(sites), b.tolerance)
+	triangulator := newIncrementalDelaunayTriangulator(subdiv)
+	if err := triangulator.insertSites(sites); err != nil {
+		return err
+	}
+	b.subdiv = subdiv
+	return nil
+}
+
+// Gets the QuadEdgeSubdivision which models the computed triangulation.
+func (b *DelaunayTriangulationBuilder) GetSubdivision() (*QuadEdgeSubdivision, error) {
+	if err := b.create(); err != nil {
+		return nil, err
+	}
+	return b.subdiv, nil
+}
+
+// Gets the edges of the computed triangulation,
+// each as a Coordinate array of 2 points.
+func (b *DelaunayTriangulationBuilder) GetEdges() ([][]Coordinate, error) {
+	if err := b.create(); err != nil {
+		return nil, err
+	}
+	return b.subdiv.getEdgeCoordinates(), nil
+}
+
+// Gets the faces of the computed triangulation,
+// each as a closed ring of 4 Coordinate(s) in counter-clockwise order.
+func (b *DelaunayTriangulationBuilder) GetTriangles() ([][]Coordinate, error) {
+	if err := b.create(); err != nil {
+		return nil, err
+	}
+	return b.subdiv.getTriangleCoordinates(false), nil
+}
+
+// Sorts the coordinates and removes the ones which are equal
+// to a previous coordinate up to a tolerance.
+func uniqueCoordinates(coords []Coordinate, tolerance float64) []Coordinate {
+	sorted := copyDeepCoordinateArray(coords)
+	sort.Sort(CoordinateSlice(sorted))
+	unique := make([]Coordinate, 0, len(sorted))
+	for _, c := range sorted {
+		if len(unique) > 0 && c.equals2DWithTolerance(&unique[len(unique)-1], tolerance) {
+			continue
+		}
+		unique = append(unique, c)
+	}
+	return unique
+}
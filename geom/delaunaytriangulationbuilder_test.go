@@ -0,0 +1,161 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestDelaunaySquareWithCentre(t *testing.T) {
+	builder := NewDelaunayTriangulationBuilder()
+	builder.SetSites(createLine(0, 0, 10, 0, 0, 10, 10, 10, 5, 5))
+	triangles, err := builder.GetTriangles()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 4, len(triangles))
+	edges, err := builder.GetEdges()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 8, len(edges))
+	for _, tri := range triangles {
+		assert2.True(t, isRing(tri))
+		assert2.Equal(t, 1, orientationIndex(tri[0], tri[1], tri[2]))
+		assert2.True(t, indexOf(NewXYCoordinate(5, 5), tri) >= 0)
+	}
+}
+
+func TestDelaunayRepeatedSitesWithTolerance(t *testing.T) {
+	builder := NewDelaunayTriangulationBuilder()
+	builder.SetSites(createLine(0, 0, 10, 0, 5, 10, 10.01, 0.01, 0, 0))
+	builder.SetTolerance(0.1)
+	triangles, err := builder.GetTriangles()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 1, len(triangles))
+}
+
+func TestDelaunayCollinearSites(t *testing.T) {
+	builder := NewDelaunayTriangulationBuilder()
+	builder.SetSites(createLine(0, 0, 10, 10, 5, 5))
+	triangles, err := builder.GetTriangles()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, len(triangles))
+	edges, err := builder.GetEdges()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 2, len(edges))
+}
+
+func TestDelaunayEmptyCircleProperty(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1234))
+	sites := make([]Coordinate, 200)
+	for i := range sites {
+		sites[i] = NewXYCoordinate(rnd.Float64()*100, rnd.Float64()*100)
+	}
+	builder := NewDelaunayTriangulationBuilder()
+	builder.SetSites(sites)
+	triangles, err := builder.GetTriangles()
+	assert2.Nil(t, err)
+	assert2.True(t, len(triangles) > len(sites))
+	for _, tri := range triangles {
+		for _, p := range sites {
+			if indexOf(p, tri) >= 0 {
+				continue
+			}
+			assert2.False(t, isInCircle(tri[0], tri[1], tri[2], p))
+		}
+	}
+}
+
+func TestConformingDelaunayConstraintSplit(t *testing.T) {
+	builder := NewConformingDelaunayTriangulationBuilder()
+	builder.SetSites(createLine(5, 1, 5, -1, 0, 0))
+	builder.SetConstraints([][]Coordinate{createLine(0, 0, 10, 0)})
+	edges, err := builder.GetEdges()
+	assert2.Nil(t, err)
+	subdiv, err := builder.GetSubdivision()
+	assert2.Nil(t, err)
+	assert2.NotNil(t, subdiv.findEdge(NewXYCoordinate(0, 0), NewXYCoordinate(5, 0)))
+	assert2.NotNil(t, subdiv.findEdge(NewXYCoordinate(5, 0), NewXYCoordinate(10, 0)))
+	for _, e := range edges {
+		// no edge crosses the constraint
+		assert2.False(t, e[0].y*e[1].y < 0)
+	}
+}
+
+func TestConformingDelaunayConstraintPresent(t *testing.T) {
+	builder := NewConformingDelaunayTriangulationBuilder()
+	builder.SetSites(createLine(0, 10, 10, 10))
+	builder.SetConstraints([][]Coordinate{createLine(0, 0, 10, 0)})
+	triangles, err := builder.GetTriangles()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 2, len(triangles))
+}
+
+func TestDelaunayNoSites(t *testing.T) {
+	builder := NewDelaunayTriangulationBuilder()
+	triangles, err := builder.GetTriangles()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, len(triangles))
+}
+
+func TestConformingDelaunaySiteOnConstraint(t *testing.T) {
+	builder := NewConformingDelaunayTriangulationBuilder()
+	builder.SetSites(createLine(3, 0, 5, 5))
+	builder.SetConstraints([][]Coordinate{createLine(0, 0, 10, 0)})
+	subdiv, err := builder.GetSubdivision()
+	assert2.Nil(t, err)
+	// the constraint is split at the site
+	assert2.NotNil(t, subdiv.findEdge(NewXYCoordinate(0, 0), NewXYCoordinate(3, 0)))
+	assert2.NotNil(t, subdiv.findEdge(NewXYCoordinate(3, 0), NewXYCoordinate(10, 0)))
+	assert2.Equal(t, 4, len(subdiv.getVertices(false)))
+}
+
+func TestConformingDelaunayConstraintVertexOnConstraint(t *testing.T) {
+	builder := NewConformingDelaunayTriangulationBuilder()
+	builder.SetConstraints([][]Coordinate{
+		createLine(0, 0, 10, 0),
+		createLine(3, 0, 3, 5),
+	})
+	subdiv, err := builder.GetSubdivision()
+	assert2.Nil(t, err)
+	assert2.NotNil(t, subdiv.findEdge(NewXYCoordinate(0, 0), NewXYCoordinate(3, 0)))
+	assert2.NotNil(t, subdiv.findEdge(NewXYCoordinate(3, 0), NewXYCoordinate(10, 0)))
+	assert2.NotNil(t, subdiv.findEdge(NewXYCoordinate(3, 0), NewXYCoordinate(3, 5)))
+	assert2.Equal(t, 4, len(subdiv.getVertices(false)))
+}
+
+func TestConformingDelaunayProjectedSplitPoint(t *testing.T) {
+	builder := NewConformingDelaunayTriangulationBuilder()
+	builder.SetSites(createLine(3, 1, 3, -1))
+	builder.SetConstraints([][]Coordinate{createLine(0, 0, 10, 0)})
+	subdiv, err := builder.GetSubdivision()
+	assert2.Nil(t, err)
+	// the constraint is split at the projection of the encroaching sites
+	assert2.NotNil(t, subdiv.findEdge(NewXYCoordinate(0, 0), NewXYCoordinate(3, 0)))
+	assert2.NotNil(t, subdiv.findEdge(NewXYCoordinate(3, 0), NewXYCoordinate(10, 0)))
+}
+
+func TestConformingDelaunayCrossingConstraints(t *testing.T) {
+	builder := NewConformingDelaunayTriangulationBuilder()
+	builder.SetConstraints([][]Coordinate{
+		createLine(0, 0, 10, 10),
+		createLine(0, 10, 10, 0),
+		// overlaps the first constraint
+		createLine(2, 2, 8, 8),
+	})
+	subdiv, err := builder.GetSubdivision()
+	assert2.Nil(t, err)
+	// the constraints are noded at their intersection
+	assert2.True(t, indexOf(NewXYCoordinate(5, 5), subdiv.getVertices(false)) >= 0)
+	assert2.NotNil(t, subdiv.findEdge(NewXYCoordinate(0, 0), NewXYCoordinate(2, 2)))
+	assert2.NotNil(t, subdiv.findEdge(NewXYCoordinate(8, 8), NewXYCoordinate(10, 10)))
+	for _, e := range subdiv.getEdgeCoordinates() {
+		// no edge crosses a constraint
+		seg := NewLineSegment(e[0], e[1])
+		for _, c := range []LineSegment{
+			NewLineSegment(NewXYCoordinate(0, 0), NewXYCoordinate(10, 10)),
+			NewLineSegment(NewXYCoordinate(0, 10), NewXYCoordinate(10, 0)),
+		} {
+			if pt, ok := seg.intersection(c); ok {
+				assert2.True(t, pt.equals2D(&e[0]) || pt.equals2D(&e[1]), "%v crosses %v", e, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package geom
+
+// Densifies lines and polygons by inserting extra vertices along the line segments.
+// The densification tolerance is used to determine the maximum length
+// of the segments: each segment is split into the smallest number of
+// equal-length subsegments which are no longer than the tolerance.
+// The Z ordinate of the inserted vertices is interpolated along the segments.
+//
+// If a PrecisionModel is set, the inserted vertices are rounded to it.
+// The original vertices are left unchanged.
+//
+// Densifying a valid polygon keeps it valid, unless the inserted
+// vertices are rounded to a coarse PrecisionModel.
+type Densifier struct {
+	distanceTolerance float64
+	pm                *PrecisionModel
+}
+
+// Creates a new densifier with a distance tolerance,
+// which must be positive.
+func NewDensifier(distanceTolerance float64) (Densifier, error) {
+	d := Densifier{}
+	if err := d.SetDistanceTolerance(distanceTolerance); err != nil {
+		return Densifier{}, err
+	}
+	return d, nil
+}
+
+// Densifies the polygons, given as lists of rings with the shell first,
+// using a given distance tolerance.
+func DensifyPolygons(polygons [][][]Coordinate, distanceTolerance float64) ([][][]Coordinate, error) {
+	d, err := NewDensifier(distanceTolerance)
+	if err != nil {
+		return nil, err
+	}
+	result := make([][][]Coordinate, len(polygons))
+	for i, rings := range polygons {
+		result[i] = d.DensifyPolygon(rings)
+	}
+	return result, nil
+}
+
+// Sets the distance tolerance for the densification. All line segments
+// in the densified geometry will be no longer than the distance tolerance.
+// The tolerance must be positive.
+func (d *Densifier) SetDistanceTolerance(distanceTolerance float64) error {
+	if err := checkDensifyTolerance(distanceTolerance); err != nil {
+		return err
+	}
+	d.distanceTolerance = distanceTolerance
+	return nil
+}
+
+// Sets the PrecisionModel which the inserted vertices are rounded to.
+func (d *Densifier) SetPrecisionModel(pm PrecisionModel) {
+	d.pm = &pm
+}
+
+// Densifies a line.
+func (d *Densifier) DensifyLine(pts []Coordinate) []Coordinate {
+	return densifyPoints(pts, d.distanceTolerance, d.pm)
+}
+
+// Densifies a polygon, given as a list of rings with the shell first.
+// The rings remain closed.
+func (d *Densifier) DensifyPolygon(rings [][]Coordinate) [][]Coordinate {
+	result := make([][]Coordinate, len(rings))
+	for i, ring := range rings {
+		result[i] = densifyPoints(ring, d.distanceTolerance, d.pm)
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package geom
+
+import (
+	"errors"
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestDensifierLine(t *testing.T) {
+	d, err := NewDensifier(0.4)
+	assert2.Nil(t, err)
+	d.SetPrecisionModel(mustFixedPrecisionModel(10))
+	densified := d.DensifyLine(createLine(0, 0, 1, 0))
+	assert2.True(t, equalArrays(createLine(0, 0, 0.3, 0, 0.7, 0, 1, 0), densified))
+	_, err = NewDensifier(-1)
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	assert2.True(t, errors.Is(d.SetDistanceTolerance(math.NaN()), ErrInvalidArgument))
+}
+
+func TestDensifyPolygons(t *testing.T) {
+	polygons := [][][]Coordinate{{
+		createLine(0, 0, 4, 0, 4, 4, 0, 4, 0, 0),
+		createLine(1, 1, 1, 2, 2, 2, 2, 1, 1, 1),
+	}}
+	densified, err := DensifyPolygons(polygons, 2)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 9, len(densified[0][0]))
+	assert2.True(t, isRing(densified[0][0]))
+	assert2.Equal(t, 5, len(densified[0][1]))
+	assert2.Equal(t, signedRingArea(polygons[0][0]), signedRingArea(densified[0][0]))
+}
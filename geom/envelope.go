@@ -265,6 +265,12 @@ func (e *Envelope) translate(transX, transY float64) {
 	e.maxY += transY
 }
 
+// Transforms this envelope to the envelope of its transformed corners.
+// A null envelope stays null.
+func (e *Envelope) transform(trans AffineTransformation) {
+	*e = trans.TransformEnvelope(*e)
+}
+
 // Computes the Coordinate of the centre of this Envelope (as long as it is non-null)
 func (e *Envelope) centre() *Coordinate {
 	if e.isNull() {
@@ -316,7 +322,7 @@ func (e *Envelope) intersectsExtent(a, b Coordinate) bool {
 		return false
 	}
 	envMinY := math.Min(a.y, b.y)
-	if envMinY < e.maxY {
+	if envMinY > e.maxY {
 		return false
 	}
 	envMaxY := math.Max(a.y, b.y)
@@ -433,7 +439,7 @@ func (e *Envelope) distance(env Envelope) float64 {
 		return dy
 	}
 	if dy == 0 {
-		return dy
+		return dx
 	}
 	return math.Sqrt(dx*dx + dy*dy)
 }
@@ -493,3 +499,22 @@ func (e *Envelope) copy() Envelope {
 		maxY: e.maxY,
 	}
 }
+
+// Tests whether another Envelope covers the same region as this one.
+// All null envelopes are equal.
+func (e *Envelope) Equals(other Envelope) bool {
+	if e.isNull() {
+		return other.isNull()
+	}
+	return e.minX == other.minX && e.maxX == other.maxX &&
+		e.minY == other.minY && e.maxY == other.maxY
+}
+
+// Computes a hash code for this envelope, which is consistent with Equals.
+// The hash code is stable across runs and platforms.
+func (e *Envelope) Hash() uint64 {
+	if e.isNull() {
+		return hashOrdinates()
+	}
+	return hashOrdinates(e.minX, e.maxX, e.minY, e.maxY)
+}
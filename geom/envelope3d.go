@@ -0,0 +1,464 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+)
+
+// Defines a box-shaped region of 3D space, with an optional range of measure values.
+// It is used to represent the bounding volume of a set of Coordinate(s),
+// e.g. the minimum and maximum x, y and z values, and to filter on
+// elevation bands or measure windows.
+//
+// The Z and M ranges are each optional.
+// A range is absent if no value has been included in it
+// (NaN ordinates are ignored).
+// An absent range places no constraint on the tests below,
+// so a 3D envelope with no Z range behaves like a 2D Envelope.
+type Envelope3D struct {
+	minX float64
+	maxX float64
+	minY float64
+	maxY float64
+	minZ float64
+	maxZ float64
+	minM float64
+	maxM float64
+}
+
+// Initialize an Envelope3D for a region defined by maximum and minimum values.
+// The M range is absent.
+func NewEnvelope3D(x1, x2, y1, y2, z1, z2 float64) Envelope3D {
+	result := NewEmptyEnvelope3D()
+	result.minX, result.maxX = math.Min(x1, x2), math.Max(x1, x2)
+	result.minY, result.maxY = math.Min(y1, y2), math.Max(y1, y2)
+	result.expandZToInclude(z1)
+	result.expandZToInclude(z2)
+	return result
+}
+
+// Creates an Envelope3D for a region defined by two Coordinate(s).
+func NewEnvelope3DFromCoordinates(p1, p2 Coordinate) Envelope3D {
+	return NewEnvelope3D(p1.x, p2.x, p1.y, p2.y, p1.z, p2.z)
+}
+
+// Creates an Envelope3D for a region defined by a single Coordinate.
+func NewPointEnvelope3D(p Coordinate) Envelope3D {
+	return NewEnvelope3D(p.x, p.x, p.y, p.y, p.z, p.z)
+}
+
+// Creates an Envelope3D from a 2D Envelope, with the given Z range.
+func NewEnvelope3DFromEnvelope(env Envelope, z1, z2 float64) Envelope3D {
+	if env.isNull() {
+		return NewEmptyEnvelope3D()
+	}
+	return NewEnvelope3D(env.minX, env.maxX, env.minY, env.maxY, z1, z2)
+}
+
+// Creates a "null" Envelope3D, that is, the envelope
+// of the empty geometry.
+func NewEmptyEnvelope3D() Envelope3D {
+	return Envelope3D{
+		minX: 0,
+		maxX: -1,
+		minY: 0,
+		maxY: -1,
+		minZ: 0,
+		maxZ: -1,
+		minM: 0,
+		maxM: -1,
+	}
+}
+
+// Makes this Envelope3D a "null" envelope, that is, the envelope
+// of the empty geometry.
+func (e *Envelope3D) setToNull() {
+	*e = NewEmptyEnvelope3D()
+}
+
+// Returns true, if this Envelope3D is a "null" Envelope3D
+func (e *Envelope3D) isNull() bool {
+	return e.maxX < e.minX
+}
+
+// Tests whether this envelope has a Z range.
+func (e *Envelope3D) hasZ() bool {
+	return e.minZ <= e.maxZ
+}
+
+// Tests whether this envelope has an M range.
+func (e *Envelope3D) hasM() bool {
+	return e.minM <= e.maxM
+}
+
+// Gets the 2D Envelope of this envelope.
+func (e *Envelope3D) getEnvelope() Envelope {
+	if e.isNull() {
+		return NewEmptyEnvelope()
+	}
+	return NewEnvelope(e.minX, e.maxX, e.minY, e.maxY)
+}
+
+// Returns the difference between the maximum and minimum x values.
+func (e *Envelope3D) width() float64 {
+	if e.isNull() {
+		return 0
+	}
+	return e.maxX - e.minX
+}
+
+// Returns the difference between the maximum and minimum y values.
+func (e *Envelope3D) height() float64 {
+	if e.isNull() {
+		return 0
+	}
+	return e.maxY - e.minY
+}
+
+// Returns the difference between the maximum and minimum z values,
+// or 0 if there is no Z range.
+func (e *Envelope3D) depth() float64 {
+	if e.isNull() || !e.hasZ() {
+		return 0
+	}
+	return e.maxZ - e.minZ
+}
+
+// Gets the volume of this envelope.
+func (e *Envelope3D) volume() float64 {
+	return e.width() * e.height() * e.depth()
+}
+
+// Computes the Coordinate of the centre of this Envelope3D (as long as it is non-null).
+// The Z ordinate is NaN if there is no Z range.
+func (e *Envelope3D) centre() *Coordinate {
+	if e.isNull() {
+		return nil
+	}
+	z := NullOrdinate
+	if e.hasZ() {
+		z = (e.minZ + e.maxZ) / 2
+	}
+	result := NewCoordinate((e.minX+e.maxX)/2, (e.minY+e.maxY)/2, z)
+	return &result
+}
+
+// Enlarges the Z range of this envelope to include a value.
+// NaN values are ignored.
+func (e *Envelope3D) expandZToInclude(z float64) {
+	if math.IsNaN(z) {
+		return
+	}
+	if !e.hasZ() {
+		e.minZ = z
+		e.maxZ = z
+		return
+	}
+	e.minZ = math.Min(e.minZ, z)
+	e.maxZ = math.Max(e.maxZ, z)
+}
+
+// Enlarges the M range of this envelope to include a value.
+// NaN values are ignored.
+func (e *Envelope3D) expandMToInclude(m float64) {
+	if math.IsNaN(m) {
+		return
+	}
+	if !e.hasM() {
+		e.minM = m
+		e.maxM = m
+		return
+	}
+	e.minM = math.Min(e.minM, m)
+	e.maxM = math.Max(e.maxM, m)
+}
+
+// Enlarges this Envelope3D so that it contains the given point.
+// A NaN z does not change the Z range.
+func (e *Envelope3D) expandToInclude(x, y, z float64) {
+	if e.isNull() {
+		e.minX = x
+		e.maxX = x
+		e.minY = y
+		e.maxY = y
+	} else {
+		e.minX = math.Min(e.minX, x)
+		e.maxX = math.Max(e.maxX, x)
+		e.minY = math.Min(e.minY, y)
+		e.maxY = math.Max(e.maxY, y)
+	}
+	e.expandZToInclude(z)
+}
+
+// Enlarges this Envelope3D so that it contains
+// the given Coordinate.
+func (e *Envelope3D) expandToIncludeCoordinate(c Coordinate) {
+	e.expandToInclude(c.x, c.y, c.z)
+}
+
+// Enlarges this Envelope3D so that it contains the given point with a measure.
+func (e *Envelope3D) expandToIncludeCoordinateM(c Coordinate, m float64) {
+	e.expandToIncludeCoordinate(c)
+	e.expandMToInclude(m)
+}
+
+// Enlarges this Envelope3D so that it contains the other Envelope3D,
+// including its Z and M ranges.
+func (e *Envelope3D) expandToIncludeEnvelope(other Envelope3D) {
+	if other.isNull() {
+		return
+	}
+	if e.isNull() {
+		*e = other
+		return
+	}
+	e.minX = math.Min(e.minX, other.minX)
+	e.maxX = math.Max(e.maxX, other.maxX)
+	e.minY = math.Min(e.minY, other.minY)
+	e.maxY = math.Max(e.maxY, other.maxY)
+	if other.hasZ() {
+		e.expandZToInclude(other.minZ)
+		e.expandZToInclude(other.maxZ)
+	}
+	if other.hasM() {
+		e.expandMToInclude(other.minM)
+		e.expandMToInclude(other.maxM)
+	}
+}
+
+// Expands this envelope by a given distance in all directions.
+// The Z range is only expanded if it is present.
+// Both positive and negative distances are supported.
+func (e *Envelope3D) expandBy(deltaX, deltaY, deltaZ float64) {
+	if e.isNull() {
+		return
+	}
+	e.minX -= deltaX
+	e.maxX += deltaX
+	e.minY -= deltaY
+	e.maxY += deltaY
+	if e.minX > e.maxX || e.minY > e.maxY {
+		e.setToNull()
+		return
+	}
+	if e.hasZ() {
+		e.minZ -= deltaZ
+		e.maxZ += deltaZ
+		if e.minZ > e.maxZ {
+			e.setToNull()
+		}
+	}
+}
+
+// Tests whether two ranges overlap.
+// An absent range (min > max) overlaps everything.
+func rangesIntersect(min1, max1, min2, max2 float64) bool {
+	if min1 > max1 || min2 > max2 {
+		return true
+	}
+	return !(min2 > max1 || max2 < min1)
+}
+
+// Tests whether the first range covers the second.
+// An absent first range covers everything; an absent
+// second range is covered by everything.
+func rangeCovers(min1, max1, min2, max2 float64) bool {
+	if min1 > max1 || min2 > max2 {
+		return true
+	}
+	return min2 >= min1 && max2 <= max1
+}
+
+// Tests whether a value lies in a range.
+// A NaN value, or an absent range, places no constraint.
+func rangeCoversValue(min, max, v float64) bool {
+	if min > max || math.IsNaN(v) {
+		return true
+	}
+	return v >= min && v <= max
+}
+
+// Tests if the region defined by other Envelope3D
+// intersects the region of this Envelope3D.
+func (e *Envelope3D) intersectsEnvelope(other Envelope3D) bool {
+	if e.isNull() || other.isNull() {
+		return false
+	}
+	return !(other.minX > e.maxX ||
+		other.maxX < e.minX ||
+		other.minY > e.maxY ||
+		other.maxY < e.minY) &&
+		rangesIntersect(e.minZ, e.maxZ, other.minZ, other.maxZ) &&
+		rangesIntersect(e.minM, e.maxM, other.minM, other.maxM)
+}
+
+// Tests if this envelope lies at least partly within an elevation band.
+func (e *Envelope3D) intersectsZRange(minZ, maxZ float64) bool {
+	if e.isNull() {
+		return false
+	}
+	return rangesIntersect(e.minZ, e.maxZ, math.Min(minZ, maxZ), math.Max(minZ, maxZ))
+}
+
+// Tests if this envelope lies at least partly within a measure window.
+func (e *Envelope3D) intersectsMRange(minM, maxM float64) bool {
+	if e.isNull() {
+		return false
+	}
+	return rangesIntersect(e.minM, e.maxM, math.Min(minM, maxM), math.Max(minM, maxM))
+}
+
+// Computes the intersection of two Envelope3D(s).
+func (e *Envelope3D) intersection(env Envelope3D) Envelope3D {
+	if !e.intersectsEnvelope(env) {
+		return NewEmptyEnvelope3D()
+	}
+	result := NewEmptyEnvelope3D()
+	result.minX = math.Max(e.minX, env.minX)
+	result.maxX = math.Min(e.maxX, env.maxX)
+	result.minY = math.Max(e.minY, env.minY)
+	result.maxY = math.Min(e.maxY, env.maxY)
+	result.minZ, result.maxZ = intersectRanges(e.minZ, e.maxZ, env.minZ, env.maxZ)
+	result.minM, result.maxM = intersectRanges(e.minM, e.maxM, env.minM, env.maxM)
+	return result
+}
+
+// Computes the intersection of two overlapping ranges,
+// where an absent range is unconstrained.
+func intersectRanges(min1, max1, min2, max2 float64) (float64, float64) {
+	if min1 > max1 {
+		return min2, max2
+	}
+	if min2 > max2 {
+		return min1, max1
+	}
+	return math.Max(min1, min2), math.Min(max1, max2)
+}
+
+// Tests if the given point lies in or on the envelope.
+// A NaN z is not tested against the Z range.
+func (e *Envelope3D) covers(x, y, z float64) bool {
+	if e.isNull() {
+		return false
+	}
+	return x >= e.minX &&
+		x <= e.maxX &&
+		y >= e.minY &&
+		y <= e.maxY &&
+		rangeCoversValue(e.minZ, e.maxZ, z)
+}
+
+// Tests if the given point lies in or on the Envelope3D.
+func (e *Envelope3D) coversCoordinate(p Coordinate) bool {
+	return e.covers(p.x, p.y, p.z)
+}
+
+// Tests if the given point with a measure lies in or on the Envelope3D.
+func (e *Envelope3D) coversCoordinateM(p Coordinate, m float64) bool {
+	return e.coversCoordinate(p) && rangeCoversValue(e.minM, e.maxM, m)
+}
+
+// Tests if the other Envelope3D
+// lies wholly inside this Envelope3D (inclusive of the boundary).
+func (e *Envelope3D) coversEnvelope(other Envelope3D) bool {
+	if e.isNull() || other.isNull() {
+		return false
+	}
+	return other.minX >= e.minX &&
+		other.maxX <= e.maxX &&
+		other.minY >= e.minY &&
+		other.maxY <= e.maxY &&
+		rangeCovers(e.minZ, e.maxZ, other.minZ, other.maxZ) &&
+		rangeCovers(e.minM, e.maxM, other.minM, other.maxM)
+}
+
+// Tests if the given point lies in or on the Envelope3D.
+//
+// Note that this is not the same definition as the SFS contains,
+// which would exclude the envelope boundary.
+func (e *Envelope3D) containsCoordinate(p Coordinate) bool {
+	return e.coversCoordinate(p)
+}
+
+// Tests if the other Envelope3D lies in or on the Envelope3D.
+//
+// Note that this is not the same definition as the SFS contains,
+// which would exclude the envelope boundary.
+func (e *Envelope3D) containsEnvelope(other Envelope3D) bool {
+	return e.coversEnvelope(other)
+}
+
+// Computes the gap between two ranges, or 0 if they overlap
+// or either is absent.
+func rangeGap(min1, max1, min2, max2 float64) float64 {
+	if min1 > max1 || min2 > max2 {
+		return 0
+	}
+	if max1 < min2 {
+		return min2 - max1
+	}
+	if min1 > max2 {
+		return min1 - max2
+	}
+	return 0
+}
+
+// Computes the 3D distance between this and another Envelope3D.
+// The distance between overlapping envelopes is 0.  Otherwise, the
+// distance is the Euclidean distance between the closest points.
+// The Z gap is ignored if either envelope has no Z range;
+// M ranges do not contribute to the distance.
+func (e *Envelope3D) distance(env Envelope3D) float64 {
+	dx := rangeGap(e.minX, e.maxX, env.minX, env.maxX)
+	dy := rangeGap(e.minY, e.maxY, env.minY, env.maxY)
+	dz := rangeGap(e.minZ, e.maxZ, env.minZ, env.maxZ)
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+func (e *Envelope3D) String() string {
+	s := fmt.Sprintf("Env3D[%g:%g,%g:%g", e.minX, e.maxX, e.minY, e.maxY)
+	if e.hasZ() {
+		s += fmt.Sprintf(",%g:%g", e.minZ, e.maxZ)
+	}
+	if e.hasM() {
+		s += fmt.Sprintf(",M %g:%g", e.minM, e.maxM)
+	}
+	return s + "]"
+}
+
+// Tests whether another Envelope3D covers the same region as this one,
+// with the same Z and M ranges.
+// All null envelopes are equal.
+func (e *Envelope3D) Equals(other Envelope3D) bool {
+	if e.isNull() {
+		return other.isNull()
+	}
+	if e.hasZ() != other.hasZ() || e.hasM() != other.hasM() {
+		return false
+	}
+	if e.hasZ() && (e.minZ != other.minZ || e.maxZ != other.maxZ) {
+		return false
+	}
+	if e.hasM() && (e.minM != other.minM || e.maxM != other.maxM) {
+		return false
+	}
+	return e.minX == other.minX && e.maxX == other.maxX &&
+		e.minY == other.minY && e.maxY == other.maxY
+}
+
+// Computes a hash code for this envelope, which is consistent with Equals.
+func (e *Envelope3D) Hash() uint64 {
+	if e.isNull() {
+		return hashOrdinates()
+	}
+	ords := []float64{e.minX, e.maxX, e.minY, e.maxY}
+	if e.hasZ() {
+		ords = append(ords, e.minZ, e.maxZ)
+	} else {
+		ords = append(ords, math.NaN(), math.NaN())
+	}
+	if e.hasM() {
+		ords = append(ords, e.minM, e.maxM)
+	}
+	return hashOrdinates(ords...)
+}
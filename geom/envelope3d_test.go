@@ -0,0 +1,92 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestEnvelope3DExpand(t *testing.T) {
+	assert := assert2.New(t)
+	e := NewEmptyEnvelope3D()
+	assert.True(e.isNull())
+	assert.Equal(0.0, e.volume())
+	e.expandToIncludeCoordinate(NewCoordinate(0, 0, 5))
+	e.expandToIncludeCoordinate(NewCoordinate(10, 20, 1))
+	e.expandToIncludeCoordinate(NewXYCoordinate(5, 30))
+	assert.True(e.hasZ())
+	assert.False(e.hasM())
+	assert.Equal(10.0, e.width())
+	assert.Equal(30.0, e.height())
+	assert.Equal(4.0, e.depth())
+	assert.Equal(1200.0, e.volume())
+	assert.Equal("Env3D[0:10,0:30,1:5]", e.String())
+
+	e.expandToIncludeCoordinateM(NewCoordinate(1, 1, 1), 100)
+	e.expandMToInclude(50)
+	assert.True(e.hasM())
+	assert.Equal("Env3D[0:10,0:30,1:5,M 50:100]", e.String())
+
+	e.expandBy(1, 1, 1)
+	expected := NewEnvelope3D(-1, 11, -1, 31, 0, 6)
+	expected.expandMToInclude(50)
+	expected.expandMToInclude(100)
+	assert.True(e.Equals(expected))
+	e.expandBy(0, 0, -4)
+	assert.True(e.isNull())
+}
+
+func TestEnvelope3DPredicates(t *testing.T) {
+	assert := assert2.New(t)
+	e1 := NewEnvelope3D(0, 10, 0, 10, 0, 10)
+	e2 := NewEnvelope3D(5, 15, 5, 15, 20, 30)
+	flat := NewEnvelope3DFromEnvelope(NewEnvelope(5, 15, 5, 15), math.NaN(), math.NaN())
+	assert.False(flat.hasZ())
+	assert.False(e1.intersectsEnvelope(e2))
+	assert.True(e1.intersectsEnvelope(flat))
+	assert.True(e1.intersectsZRange(10, 20))
+	assert.False(e1.intersectsZRange(11, 20))
+	assert.True(e1.covers(5, 5, 5))
+	assert.False(e1.covers(5, 5, 11))
+	assert.True(e1.covers(5, 5, math.NaN()))
+	assert.True(e1.coversEnvelope(NewEnvelope3D(1, 2, 1, 2, 1, 2)))
+	assert.False(e1.coversEnvelope(NewEnvelope3D(1, 2, 1, 2, 1, 12)))
+	assert.True(e1.containsCoordinate(NewCoordinate(0, 10, 10)))
+
+	inter := e1.intersection(flat)
+	assert.True(inter.Equals(NewEnvelope3D(5, 10, 5, 10, 0, 10)))
+	disjoint := e1.intersection(e2)
+	assert.True(disjoint.isNull())
+
+	e1.expandMToInclude(0)
+	e1.expandMToInclude(60)
+	assert.True(e1.intersectsMRange(50, 70))
+	assert.False(e1.intersectsMRange(61, 70))
+	assert.True(e1.coversCoordinateM(NewCoordinate(1, 1, 1), 30))
+	assert.False(e1.coversCoordinateM(NewCoordinate(1, 1, 1), 61))
+	// an envelope with no M range matches any measure window
+	e3 := NewEnvelope3D(0, 1, 0, 1, 0, 1)
+	assert.True(e3.intersectsMRange(61, 70))
+}
+
+func TestEnvelope3DDistance(t *testing.T) {
+	e1 := NewEnvelope3D(0, 1, 0, 1, 0, 1)
+	assert2.Equal(t, 0.0, e1.distance(NewEnvelope3D(1, 2, 0, 1, 0, 1)))
+	assert2.Equal(t, 2.0, e1.distance(NewEnvelope3D(0, 1, 0, 1, 3, 4)))
+	assert2.Equal(t, 5.0, e1.distance(NewEnvelope3D(4, 5, 0, 1, 5, 6)))
+}
+
+func TestEnvelope3DEquals(t *testing.T) {
+	e1 := NewEnvelope3D(0, 1, 0, 1, 0, 1)
+	e2 := NewEnvelope3DFromCoordinates(NewCoordinate(1, 1, 1), NewCoordinate(0, 0, 0))
+	assert2.True(t, e1.Equals(e2))
+	assert2.Equal(t, e1.Hash(), e2.Hash())
+	flat := NewEnvelope3DFromEnvelope(NewEnvelope(0, 1, 0, 1), math.NaN(), math.NaN())
+	assert2.False(t, e1.Equals(flat))
+	e3 := NewEmptyEnvelope3D()
+	assert2.True(t, e3.Equals(NewEmptyEnvelope3D()))
+	p := NewPointEnvelope3D(NewCoordinate(1, 2, 3))
+	assert2.Equal(t, NewCoordinate(1, 2, 3), *p.centre())
+	env := p.getEnvelope()
+	assert2.True(t, env.Equals(NewEnvelope(1, 1, 2, 2)))
+}
@@ -0,0 +1,131 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// An Envelope with a quick.Generator, whose bounds are small integers
+// so that generated envelopes often overlap or touch.
+// One in ten generated envelopes is null.
+type quickEnvelope struct {
+	Envelope
+}
+
+func (quickEnvelope) Generate(rnd *rand.Rand, size int) reflect.Value {
+	if rnd.Intn(10) == 0 {
+		return reflect.ValueOf(quickEnvelope{NewEmptyEnvelope()})
+	}
+	ord := func() float64 {
+		return float64(rnd.Intn(2*size+1) - size)
+	}
+	return reflect.ValueOf(quickEnvelope{NewEnvelope(ord(), ord(), ord(), ord())})
+}
+
+// A closed ring with a quick.Generator, together with a valid scroll index.
+type quickRing struct {
+	pts   []Coordinate
+	index int
+}
+
+func (quickRing) Generate(rnd *rand.Rand, size int) reflect.Value {
+	n := 3 + rnd.Intn(size+1)
+	pts := make([]Coordinate, n+1)
+	for i := 0; i < n; i++ {
+		pts[i] = NewXYCoordinate(float64(rnd.Intn(100)), float64(rnd.Intn(100)))
+	}
+	pts[n] = pts[0]
+	return reflect.ValueOf(quickRing{pts: pts, index: rnd.Intn(n)})
+}
+
+func checkProperty(t *testing.T, property interface{}) {
+	assert2.Nil(t, quick.Check(property, &quick.Config{MaxCount: 2000}))
+}
+
+func TestEnvelopeIntersectionIsCommutative(t *testing.T) {
+	checkProperty(t, func(a, b quickEnvelope) bool {
+		ab := a.intersection(b.Envelope)
+		ba := b.intersection(a.Envelope)
+		return ab.Equals(ba)
+	})
+}
+
+func TestEnvelopeIntersectionIsCoveredByBoth(t *testing.T) {
+	checkProperty(t, func(a, b quickEnvelope) bool {
+		ab := a.intersection(b.Envelope)
+		if ab.isNull() {
+			return !a.intersectsEnvelope(b.Envelope)
+		}
+		return a.coversEnvelope(ab) && b.coversEnvelope(ab)
+	})
+}
+
+func TestEnvelopeExpandIsMonotone(t *testing.T) {
+	checkProperty(t, func(a, b, c quickEnvelope) bool {
+		expanded := a.copy()
+		expanded.expandToIncludeEnvelope(b.Envelope)
+		if !a.isNull() && !expanded.coversEnvelope(a.Envelope) {
+			return false
+		}
+		if !b.isNull() && !expanded.coversEnvelope(b.Envelope) {
+			return false
+		}
+		// anything covered before expanding remains covered
+		return !a.coversEnvelope(c.Envelope) || expanded.coversEnvelope(c.Envelope)
+	})
+}
+
+func TestEnvelopeCoversImpliesIntersects(t *testing.T) {
+	checkProperty(t, func(a, b quickEnvelope) bool {
+		return !a.coversEnvelope(b.Envelope) || a.intersectsEnvelope(b.Envelope)
+	})
+}
+
+func TestEnvelopeDisjointIsNotIntersects(t *testing.T) {
+	checkProperty(t, func(a, b quickEnvelope) bool {
+		return a.disjoint(b.Envelope) != a.intersectsEnvelope(b.Envelope)
+	})
+}
+
+func TestEnvelopeDistanceIsZeroWhenIntersecting(t *testing.T) {
+	checkProperty(t, func(a, b quickEnvelope) bool {
+		if a.isNull() || b.isNull() {
+			return true
+		}
+		return (a.distance(b.Envelope) == 0) == a.intersectsEnvelope(b.Envelope) &&
+			a.distance(b.Envelope) == b.distance(a.Envelope)
+	})
+}
+
+func TestEnvelopeIntersectsExtentMatchesEnvelope(t *testing.T) {
+	checkProperty(t, func(a, b quickEnvelope) bool {
+		if b.isNull() {
+			return true
+		}
+		p := NewXYCoordinate(b.minX, b.maxY)
+		q := NewXYCoordinate(b.maxX, b.minY)
+		return a.intersectsExtent(p, q) == a.intersectsEnvelope(b.Envelope)
+	})
+}
+
+func TestScrollPreservesRing(t *testing.T) {
+	checkProperty(t, func(r quickRing) bool {
+		scrolled := copyDeepCoordinateArray(r.pts)
+		if err := scroll(scrolled, r.index, true); err != nil {
+			return false
+		}
+		if !isRing(scrolled) {
+			return false
+		}
+		n := len(r.pts) - 1
+		for i := 0; i < n; i++ {
+			if !scrolled[i].equals2D(&r.pts[(r.index+i)%n]) {
+				return false
+			}
+		}
+		return true
+	})
+}
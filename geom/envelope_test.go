@@ -2,6 +2,7 @@ package geom
 
 import (
 	assert2 "github.com/stretchr/testify/assert"
+	"math"
 	"testing"
 )
 
@@ -229,3 +230,27 @@ func checkIntersects(a1x, a1y, a2x, a2y, b1x, b1y, b2x, b2y float64, expected bo
 	assert.Equal(expected, intersect(a1, a2, b1, b2))
 	assert.Equal(expected, a.intersectsExtent(b1, b2))
 }
+
+func TestTranslate(t *testing.T) {
+	assert := assert2.New(t)
+	env := NewEnvelope(0, 10, 0, 20)
+	env.translate(1, -2)
+	assert.Equal(NewEnvelope(1, 11, -2, 18), env)
+	empty := NewEmptyEnvelope()
+	empty.translate(1, -2)
+	assert.True(empty.isNull())
+	// an infinite range in X leaves the Y range untouched
+	strip := NewEnvelope(math.Inf(-1), math.Inf(1), 0, 20)
+	strip.translate(1, -2)
+	assert.Equal(NewEnvelope(math.Inf(-1), math.Inf(1), -2, 18), strip)
+}
+
+func TestEnvelopeTransform(t *testing.T) {
+	assert := assert2.New(t)
+	env := NewEnvelope(0, 10, 0, 20)
+	env.transform(NewRotationTransformation(math.Pi / 2))
+	assert.InDelta(-20, env.minX, 1e-12)
+	assert.InDelta(0, env.maxX, 1e-12)
+	assert.InDelta(0, env.minY, 1e-12)
+	assert.InDelta(10, env.maxY, 1e-12)
+}
@@ -0,0 +1,140 @@
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// Reported when an ordinate index does not name an ordinate
+	// of a Coordinate.
+	ErrInvalidOrdinate = errors.New("Invalid ordinate index")
+	// Reported when an array index lies outside of the array.
+	ErrIndexOutOfRange = errors.New("Index out of range")
+	// Reported when an operation requires at least one Coordinate.
+	ErrEmpty = errors.New("Coordinate array is empty")
+	// Reported when coordinates which must form a ring are not closed
+	// or have too few points.
+	ErrNotARing = errors.New("Coordinates do not form a ring")
+	// Reported when an algorithm encounters an invalid topology,
+	// usually caused by robustness problems in the input.
+	ErrTopology = errors.New("Topology error")
+	// Reported when an argument of a function or method
+	// lies outside of its valid range.
+	ErrInvalidArgument = errors.New("Invalid argument")
+	// Reported when the inverse of a singular transformation is requested.
+	ErrNonInvertible = errors.New("Transformation is non-invertible")
+	// Reported when a Coordinate lies outside of the domain
+	// of a coordinate transformation.
+	ErrOutOfDomain = errors.New("Coordinate outside of the transformation domain")
+)
+
+// An error located at a Coordinate.
+// The kind of error is one of the Err variables of this package,
+// so it can be tested with errors.Is, while the offending
+// Coordinate can be retrieved with errors.As.
+type CoordinateError struct {
+	Err error
+	Pt  Coordinate
+	Msg string
+}
+
+// Creates a CoordinateError of the given kind at a Coordinate.
+func NewCoordinateError(err error, pt Coordinate, msg string) *CoordinateError {
+	return &CoordinateError{
+		Err: err,
+		Pt:  pt,
+		Msg: msg,
+	}
+}
+
+func (e *CoordinateError) Error() string {
+	if e.Msg == "" {
+		return fmt.Sprintf("%v at %v", e.Err, e.Pt)
+	}
+	return fmt.Sprintf("%v: %s at %v", e.Err, e.Msg, e.Pt)
+}
+
+// Gets the kind of this error.
+func (e *CoordinateError) Unwrap() error {
+	return e.Err
+}
+
+// An error reported when an operation such as noding, polygon building
+// or triangulation finds that the topology of its input or of an
+// intermediate result is invalid.
+// It records the name of the failing operation and the location of the failure,
+// and can be tested with errors.Is(err, ErrTopology).
+//
+// The WKT of the inputs of the operation is recorded as well,
+// and can be printed with DebugDump to reproduce the failure.
+type TopologyError struct {
+	Op     string
+	Pt     Coordinate
+	Msg    string
+	inputs []string
+}
+
+// Creates a TopologyError for an operation failing at a Coordinate.
+func NewTopologyError(op string, pt Coordinate, msg string) *TopologyError {
+	return &TopologyError{
+		Op:  op,
+		Pt:  pt,
+		Msg: msg,
+	}
+}
+
+func (e *TopologyError) Error() string {
+	return fmt.Sprintf("%v in %s: %s at %v", ErrTopology, e.Op, e.Msg, e.Pt)
+}
+
+// Gets the kind of this error, which is always ErrTopology.
+func (e *TopologyError) Unwrap() error {
+	return ErrTopology
+}
+
+// Records lines which are inputs of the failing operation.
+func (e *TopologyError) addInputLines(lines ...[]Coordinate) *TopologyError {
+	for _, line := range lines {
+		e.inputs = append(e.inputs, toLineStringWKT(line...))
+	}
+	return e
+}
+
+// Records a polygon, given as a list of rings with the shell first,
+// which is an input of the failing operation.
+func (e *TopologyError) addInputPolygon(rings [][]Coordinate) *TopologyError {
+	e.inputs = append(e.inputs, toPolygonWKT(rings))
+	return e
+}
+
+// Gets the WKT of the recorded inputs of the failing operation.
+func (e *TopologyError) GetInputs() []string {
+	return e.inputs
+}
+
+// Describes the error for debugging: the error message, followed by
+// the location as a WKT POINT and the WKT of each recorded input,
+// one per line.
+func (e *TopologyError) DebugDump() string {
+	var sb strings.Builder
+	sb.WriteString(e.Error())
+	sb.WriteString("\n")
+	sb.WriteString(toPointWKT(e.Pt))
+	for _, input := range e.inputs {
+		sb.WriteString("\n")
+		sb.WriteString(input)
+	}
+	return sb.String()
+}
+
+// Creates an error for an index which lies outside of an array of the given length.
+func indexOutOfRangeError(index, length int) error {
+	return fmt.Errorf("%w: %d (length %d)", ErrIndexOutOfRange, index, length)
+}
+
+// Creates an error for an invalid argument, described by a format and its values.
+func invalidArgumentError(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidArgument, fmt.Sprintf(format, a...))
+}
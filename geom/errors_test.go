@@ -0,0 +1,110 @@
+package geom
+
+import (
+	"errors"
+	assert2 "github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOrdinateErrors(t *testing.T) {
+	c := NewCoordinate(1, 2, 3)
+	z, err := c.getOrdinate(Z)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 3.0, z)
+	_, err = c.getOrdinate(3)
+	assert2.True(t, errors.Is(err, ErrInvalidOrdinate))
+	var coordErr *CoordinateError
+	assert2.True(t, errors.As(err, &coordErr))
+	assert2.Equal(t, c, coordErr.Pt)
+	assert2.Equal(t, "Invalid ordinate index: 3 at (1, 2, 3)", err.Error())
+
+	assert2.Nil(t, c.setOrdinate(X, 5))
+	assert2.Equal(t, 5.0, c.GetX())
+	err = c.setOrdinate(-1, 5)
+	assert2.True(t, errors.Is(err, ErrInvalidOrdinate))
+	assert2.False(t, errors.Is(err, ErrTopology))
+}
+
+func TestCoordinateErrorWithoutMessage(t *testing.T) {
+	err := NewCoordinateError(ErrTopology, NewCoordinate(1, 2, 3), "")
+	assert2.Equal(t, "Topology error at (1, 2, 3)", err.Error())
+	assert2.Equal(t, ErrTopology, errors.Unwrap(err))
+}
+
+func TestEditRingsNotARing(t *testing.T) {
+	editor := NewCoordinateEditor(CoordinateOperationFunc(func(coords []Coordinate) []Coordinate {
+		return coords[:len(coords)-1]
+	}))
+	_, err := editor.EditRings([][]Coordinate{createLine(0, 0, 1, 0, 1, 1, 0, 0)})
+	assert2.True(t, errors.Is(err, ErrNotARing))
+}
+
+func TestInvalidArgumentErrors(t *testing.T) {
+	_, err := NewReflectionTransformation(1, 1, 1, 1)
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	assert2.Equal(t, "Invalid argument: Reflection line points must be distinct", err.Error())
+	_, err = NewReflectionTransformationThroughOrigin(0, 0)
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = NewAffineTransformationFromControlVectors(createLine(0, 0), createLine(0, 0, 1, 1))
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = NewAffineTransformationFromControlVectors(createLine(0, 0, 1, 1, 2, 2), createLine(0, 0, 1, 0, 0, 1))
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = NewAffineTransformationFromBaseLines(NewXYCoordinate(1, 1), NewXYCoordinate(1, 1),
+		NewXYCoordinate(0, 0), NewXYCoordinate(1, 0))
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+
+	_, err = NewCoordinateTransform(1234, SRID_WGS84)
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	assert2.Equal(t, "Invalid argument: Unsupported SRID: 1234", err.Error())
+	_, err = NewUTMTransform(61, true)
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+
+	hull := NewConcaveHull(createLine(0, 0, 1, 0, 0, 1))
+	assert2.True(t, errors.Is(hull.SetMaximumEdgeLength(-1), ErrInvalidArgument))
+	assert2.True(t, errors.Is(hull.SetMaximumEdgeLengthRatio(2), ErrInvalidArgument))
+	polygonsHull := NewConcaveHullOfPolygons(nil)
+	assert2.True(t, errors.Is(polygonsHull.SetMaximumEdgeLength(-1), ErrInvalidArgument))
+	assert2.True(t, errors.Is(polygonsHull.SetMaximumEdgeLengthRatio(-0.5), ErrInvalidArgument))
+
+	_, err = ParsePrecisionModel("FIXED(Scale=abc)")
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+	assert2.Equal(t, "Invalid argument: Invalid precision model parameter: Scale=abc", err.Error())
+	_, err = ParsePrecisionModel("EXACT")
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func TestNonInvertibleError(t *testing.T) {
+	trans := NewScaleTransformation(0, 1)
+	_, err := trans.GetInverse()
+	assert2.True(t, errors.Is(err, ErrNonInvertible))
+	assert2.False(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func TestTopologyError(t *testing.T) {
+	err := NewTopologyError("noding", NewXYCoordinate(1, 2), "found a non-noded intersection").
+		addInputLines(createLine(0, 0, 2, 4), createLine(0, 4, 2, 0))
+	assert2.True(t, errors.Is(err, ErrTopology))
+	assert2.Equal(t, "Topology error in noding: found a non-noded intersection at (1, 2, NaN)", err.Error())
+	assert2.Equal(t, "Topology error in noding: found a non-noded intersection at (1, 2, NaN)\n"+
+		"POINT ( 1 2 )\n"+
+		"LINESTRING ( 0 0, 2 4 )\n"+
+		"LINESTRING ( 0 4, 2 0 )", err.DebugDump())
+}
+
+func TestBuildPolygonsUnclosed(t *testing.T) {
+	_, err := buildPolygons([][]Coordinate{createLine(0, 0, 1, 0, 1, 1)})
+	var topoErr *TopologyError
+	assert2.True(t, errors.As(err, &topoErr))
+	assert2.Equal(t, "polygon building", topoErr.Op)
+	assert2.True(t, topoErr.Pt.equals2D(&Coordinate{x: 1, y: 1}))
+	assert2.Equal(t, []string{"LINESTRING ( 0 0, 1 0, 1 1 )"}, topoErr.GetInputs())
+}
+
+func TestPolygonWKT(t *testing.T) {
+	assert2.Equal(t, "POLYGON EMPTY", toPolygonWKT(nil))
+	assert2.Equal(t, "POLYGON ( ( 0 0, 1 0, 1 1, 0 0 ), ( 0.2 0.1, 0.8 0.7, 0.8 0.1, 0.2 0.1 ) )",
+		toPolygonWKT([][]Coordinate{
+			createLine(0, 0, 1, 0, 1, 1, 0, 0),
+			createLine(0.2, 0.1, 0.8, 0.7, 0.8, 0.1, 0.2, 0.1),
+		}))
+}
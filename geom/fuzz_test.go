@@ -0,0 +1,120 @@
+//go:build go1.18
+// +build go1.18
+
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func hasNaN(values ...float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func FuzzEnvelope(f *testing.F) {
+	f.Add(0.0, 10.0, 0.0, 10.0, 5.0, 15.0, 5.0, 15.0)
+	f.Add(0.0, 1.0, 0.0, 1.0, 2.0, 3.0, 0.0, 1.0)
+	f.Add(-1.0, 1.0, -1.0, 1.0, math.Inf(1), math.Inf(-1), 0.0, 0.0)
+	f.Fuzz(func(t *testing.T, ax1, ax2, ay1, ay2, bx1, bx2, by1, by2 float64) {
+		if hasNaN(ax1, ax2, ay1, ay2, bx1, bx2, by1, by2) {
+			return
+		}
+		a := NewEnvelope(ax1, ax2, ay1, ay2)
+		b := NewEnvelope(bx1, bx2, by1, by2)
+		ab := a.intersection(b)
+		ba := b.intersection(a)
+		if !ab.Equals(ba) {
+			t.Errorf("intersection is not commutative: %v, %v", ab.String(), ba.String())
+		}
+		if a.coversEnvelope(b) && !a.intersectsEnvelope(b) {
+			t.Errorf("%v covers but does not intersect %v", a.String(), b.String())
+		}
+		if a.intersectsEnvelope(b) == a.disjoint(b) {
+			t.Errorf("%v both intersects and is disjoint from %v", a.String(), b.String())
+		}
+		expanded := a.copy()
+		expanded.expandToIncludeEnvelope(b)
+		if !expanded.coversEnvelope(a) || !expanded.coversEnvelope(b) {
+			t.Errorf("%v does not cover %v and %v", expanded.String(), a.String(), b.String())
+		}
+	})
+}
+
+func FuzzScroll(f *testing.F) {
+	f.Add([]byte{0, 0, 10, 0, 10, 10, 0, 10}, 2, true)
+	f.Add([]byte{1, 2, 3}, -1, false)
+	f.Fuzz(func(t *testing.T, ords []byte, index int, ensureRing bool) {
+		pts := make([]Coordinate, 0, len(ords)/2+1)
+		for i := 0; i+1 < len(ords); i += 2 {
+			pts = append(pts, NewXYCoordinate(float64(ords[i]), float64(ords[i+1])))
+		}
+		if ensureRing && len(pts) > 0 {
+			pts = append(pts, pts[0])
+		}
+		wasRing := isRing(pts)
+		if err := scroll(pts, index, ensureRing); err != nil {
+			return
+		}
+		if wasRing && ensureRing && !isRing(pts) {
+			t.Errorf("scrolling by %d did not preserve the ring", index)
+		}
+	})
+}
+
+func FuzzParsePrecisionModel(f *testing.F) {
+	f.Add("FLOATING")
+	f.Add("FLOATING_SINGLE")
+	f.Add("FIXED(Scale=1000)")
+	f.Add("FIXED(GridSize=0.5,OffsetX=1,OffsetY=2,Rounding=HALF_EVEN)")
+	// an unknown key, which is rejected
+	f.Add("FIXED(Scale=10,RoundingMode=HALF_EVEN)")
+	f.Fuzz(func(t *testing.T, s string) {
+		pm, err := ParsePrecisionModel(s)
+		if err != nil {
+			return
+		}
+		// the model is usable whatever the input
+		p := NewXYCoordinate(1.2345, -6.789)
+		pm.makePreciseCoordinate(&p)
+		_ = pm.String()
+	})
+}
+
+func FuzzCircularString(f *testing.F) {
+	f.Add(0.0, 0.0, 1.0, 1.0, 2.0, 0.0, 0.01)
+	f.Add(0.0, 0.0, 2.0, 0.0, 0.0, 0.0, 0.1)
+	f.Add(0.0, 0.0, 1.0, 0.0, 2.0, 0.0, 1.0)
+	f.Fuzz(func(t *testing.T, x0, y0, x1, y1, x2, y2, tolerance float64) {
+		if hasNaN(x0, y0, x1, y1, x2, y2) {
+			return
+		}
+		for _, v := range []float64{x0, y0, x1, y1, x2, y2} {
+			// keep the linearization to a bounded number of points
+			if math.Abs(v) > 1e6 {
+				return
+			}
+		}
+		if !(tolerance > 1e-6) {
+			return
+		}
+		cs, err := NewCircularString(createLine(x0, y0, x1, y1, x2, y2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		env := cs.GetEnvelope()
+		for _, p := range cs.GetCoordinates() {
+			if !env.coversCoordinate(p) {
+				t.Errorf("%v does not cover control point %v", env.String(), p)
+			}
+		}
+		_, _ = cs.Linearize(tolerance)
+		_ = cs.String()
+		_ = cs.ToWKB()
+	})
+}
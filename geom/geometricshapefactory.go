@@ -0,0 +1,348 @@
+package geom
+
+import "math"
+
+// The fewest points from which a shape can be created.
+const minShapePoints = 3
+
+// Computes various kinds of common geometric shapes.
+// Provides various ways of specifying the location and extent
+// and rotations of the generated shapes,
+// as well as number of line segments used to form them.
+//
+// Polygonal shapes are returned as closed rings in counter-clockwise
+// order, and linear shapes as lines.
+//
+// Example of usage:
+//
+//	gsf := NewGeometricShapeFactory()
+//	gsf.SetSize(100)
+//	gsf.SetNumPoints(100)
+//	gsf.SetBase(NewXYCoordinate(100, 100))
+//	gsf.SetRotation(0.5)
+//	rect := gsf.CreateRectangle()
+type GeometricShapeFactory struct {
+	pm            PrecisionModel
+	dim           shapeDimensions
+	nPts          int
+	rotationAngle float64
+}
+
+// The location and size of the shapes to create.
+// The location is given either by the base (lower-left) point
+// or by the centre point; if neither is set, the base is the origin.
+type shapeDimensions struct {
+	base   *Coordinate
+	centre *Coordinate
+	width  float64
+	height float64
+}
+
+func (d *shapeDimensions) getMinSize() float64 {
+	return math.Min(d.width, d.height)
+}
+
+func (d *shapeDimensions) getEnvelope() Envelope {
+	if d.base != nil {
+		return NewEnvelope(d.base.x, d.base.x+d.width, d.base.y, d.base.y+d.height)
+	}
+	if d.centre != nil {
+		return NewEnvelope(d.centre.x-d.width/2, d.centre.x+d.width/2,
+			d.centre.y-d.height/2, d.centre.y+d.height/2)
+	}
+	return NewEnvelope(0, d.width, 0, d.height)
+}
+
+func (d *shapeDimensions) getCentre() Coordinate {
+	if d.centre == nil {
+		env := d.getEnvelope()
+		return *env.centre()
+	}
+	return *d.centre
+}
+
+// Creates a shape factory which will create shapes using
+// a floating PrecisionModel, with 100 points.
+func NewGeometricShapeFactory() GeometricShapeFactory {
+	return NewGeometricShapeFactoryFromPrecisionModel(NewDefaultPrecisionModel())
+}
+
+// Creates a shape factory which will create shapes
+// whose coordinates are rounded to the given PrecisionModel.
+func NewGeometricShapeFactoryFromPrecisionModel(pm PrecisionModel) GeometricShapeFactory {
+	return GeometricShapeFactory{
+		pm:   pm,
+		nPts: 100,
+	}
+}
+
+// Sets the location of the shape by specifying the base coordinate
+// (which in most cases is the lower left point of the envelope containing the shape).
+func (f *GeometricShapeFactory) SetBase(base Coordinate) {
+	f.dim.base = &base
+	f.dim.centre = nil
+}
+
+// Sets the location of the shape by specifying the centre of
+// the shape's bounding box
+func (f *GeometricShapeFactory) SetCentre(centre Coordinate) {
+	f.dim.centre = &centre
+	f.dim.base = nil
+}
+
+// Sets the location and size of the shape by specifying the
+// Envelope which contains it.
+func (f *GeometricShapeFactory) SetEnvelope(env Envelope) {
+	f.dim.width = env.width()
+	f.dim.height = env.height()
+	base := NewXYCoordinate(env.minX, env.minY)
+	f.dim.base = &base
+	f.dim.centre = env.centre()
+}
+
+// Sets the total number of points in the created shape.
+// The number of points in the closed rings of polygons
+// is one more, as the first point is repeated at the end.
+// Returns ErrInvalidArgument if fewer than 3 points are requested,
+// in which case the number of points is unchanged.
+func (f *GeometricShapeFactory) SetNumPoints(nPts int) error {
+	if nPts < minShapePoints {
+		return invalidArgumentError("Shapes must have at least %d points: %d", minShapePoints, nPts)
+	}
+	f.nPts = nPts
+	return nil
+}
+
+// Sets the size of the extent of the shape in both x and y directions.
+func (f *GeometricShapeFactory) SetSize(size float64) {
+	f.dim.width = size
+	f.dim.height = size
+}
+
+// Sets the width of the shape.
+func (f *GeometricShapeFactory) SetWidth(width float64) {
+	f.dim.width = width
+}
+
+// Sets the height of the shape.
+func (f *GeometricShapeFactory) SetHeight(height float64) {
+	f.dim.height = height
+}
+
+// Sets the rotation angle to use for the shape.
+// The rotation is applied relative to the centre of the shape,
+// in radians, counter-clockwise.
+func (f *GeometricShapeFactory) SetRotation(radians float64) {
+	f.rotationAngle = radians
+}
+
+// Rotates the coordinates about the centre of the shape,
+// and then rounds them to the PrecisionModel.
+func (f *GeometricShapeFactory) finish(pts []Coordinate) []Coordinate {
+	if f.rotationAngle != 0 {
+		centre := f.dim.getCentre()
+		trans := NewRotationTransformationAroundPoint(f.rotationAngle, centre.x, centre.y)
+		trans.TransformCoordinates(pts)
+	}
+	for i := range pts {
+		f.pm.makePreciseCoordinate(&pts[i])
+	}
+	return pts
+}
+
+// Creates a rectangular polygon.
+func (f *GeometricShapeFactory) CreateRectangle() []Coordinate {
+	nSide := f.nPts / 4
+	if nSide < 1 {
+		nSide = 1
+	}
+	env := f.dim.getEnvelope()
+	xSegLen := env.width() / float64(nSide)
+	ySegLen := env.height() / float64(nSide)
+
+	pts := make([]Coordinate, 0, 4*nSide+1)
+	for i := 0; i < nSide; i++ {
+		pts = append(pts, NewXYCoordinate(env.minX+float64(i)*xSegLen, env.minY))
+	}
+	for i := 0; i < nSide; i++ {
+		pts = append(pts, NewXYCoordinate(env.maxX, env.minY+float64(i)*ySegLen))
+	}
+	for i := 0; i < nSide; i++ {
+		pts = append(pts, NewXYCoordinate(env.maxX-float64(i)*xSegLen, env.maxY))
+	}
+	for i := 0; i < nSide; i++ {
+		pts = append(pts, NewXYCoordinate(env.minX, env.maxY-float64(i)*ySegLen))
+	}
+	pts = append(pts, pts[0])
+	return f.finish(pts)
+}
+
+// Creates a circular or elliptical polygon.
+func (f *GeometricShapeFactory) CreateCircle() []Coordinate {
+	return f.CreateEllipse()
+}
+
+// Creates an elliptical polygon.
+// If the supplied envelope is square the
+// result will be a circle.
+func (f *GeometricShapeFactory) CreateEllipse() []Coordinate {
+	env := f.dim.getEnvelope()
+	xRadius := env.width() / 2
+	yRadius := env.height() / 2
+	centreX := env.minX + xRadius
+	centreY := env.minY + yRadius
+
+	pts := make([]Coordinate, 0, f.nPts+1)
+	for i := 0; i < f.nPts; i++ {
+		ang := float64(i) * (2 * math.Pi / float64(f.nPts))
+		pts = append(pts, NewXYCoordinate(xRadius*snappedCos(ang)+centreX, yRadius*snappedSin(ang)+centreY))
+	}
+	pts = append(pts, pts[0])
+	return f.finish(pts)
+}
+
+// Creates a squircular polygon, which is a supercircle of power 4.
+func (f *GeometricShapeFactory) CreateSquircle() []Coordinate {
+	return f.CreateSupercircle(4)
+}
+
+// Creates a supercircular polygon, of a given positive power.
+// The supercircle has the diameter of the smaller of
+// the width and height of the shape.
+// The number of points is rounded down to a multiple of 8.
+func (f *GeometricShapeFactory) CreateSupercircle(power float64) []Coordinate {
+	recipPow := 1 / power
+	radius := f.dim.getMinSize() / 2
+	centre := f.dim.getCentre()
+
+	r4 := math.Pow(radius, power)
+	y0 := radius
+	xyInt := math.Pow(r4/2, recipPow)
+
+	nSegsInOct := f.nPts / 8
+	if nSegsInOct < 1 {
+		nSegsInOct = 1
+	}
+	pts := make([]Coordinate, nSegsInOct*8+1)
+	xInc := xyInt / float64(nSegsInOct)
+	coordTrans := func(x, y float64) Coordinate {
+		return NewXYCoordinate(x+centre.x, y+centre.y)
+	}
+	for i := 0; i <= nSegsInOct; i++ {
+		x := 0.0
+		y := y0
+		if i != 0 {
+			x = xInc * float64(i)
+			y = math.Pow(r4-math.Pow(x, power), recipPow)
+		}
+		pts[i] = coordTrans(x, y)
+		pts[2*nSegsInOct-i] = coordTrans(y, x)
+		pts[2*nSegsInOct+i] = coordTrans(y, -x)
+		pts[4*nSegsInOct-i] = coordTrans(x, -y)
+		pts[4*nSegsInOct+i] = coordTrans(-x, -y)
+		pts[6*nSegsInOct-i] = coordTrans(-y, -x)
+		pts[6*nSegsInOct+i] = coordTrans(-y, x)
+		pts[8*nSegsInOct-i] = coordTrans(-x, y)
+	}
+	pts[len(pts)-1] = pts[0]
+	// the points run clockwise from the top
+	reverse(pts)
+	return f.finish(pts)
+}
+
+// Creates a superelliptical polygon of a given positive power,
+// filling the width and height of the shape.
+// A power of 2 gives an ellipse; larger powers give
+// shapes closer to a rectangle, and smaller powers
+// shapes with concave sides.
+func (f *GeometricShapeFactory) CreateSuperellipse(power float64) []Coordinate {
+	env := f.dim.getEnvelope()
+	xRadius := env.width() / 2
+	yRadius := env.height() / 2
+	centreX := env.minX + xRadius
+	centreY := env.minY + yRadius
+	exp := 2 / power
+
+	pts := make([]Coordinate, 0, f.nPts+1)
+	for i := 0; i < f.nPts; i++ {
+		ang := float64(i) * (2 * math.Pi / float64(f.nPts))
+		cos := snappedCos(ang)
+		sin := snappedSin(ang)
+		x := xRadius*math.Copysign(math.Pow(math.Abs(cos), exp), cos) + centreX
+		y := yRadius*math.Copysign(math.Pow(math.Abs(sin), exp), sin) + centreY
+		pts = append(pts, NewXYCoordinate(x, y))
+	}
+	pts = append(pts, pts[0])
+	return f.finish(pts)
+}
+
+// Creates an elliptical arc, as a line.
+// The arc is always created in a counter-clockwise direction.
+// An angle extent which is not positive, or greater than a full circle,
+// creates a full circle.
+func (f *GeometricShapeFactory) CreateArc(startAng, angExtent float64) []Coordinate {
+	env := f.dim.getEnvelope()
+	xRadius := env.width() / 2
+	yRadius := env.height() / 2
+	centreX := env.minX + xRadius
+	centreY := env.minY + yRadius
+
+	angSize := angExtent
+	if angSize <= 0 || angSize > 2*math.Pi {
+		angSize = 2 * math.Pi
+	}
+	angInc := angSize / float64(f.nPts-1)
+
+	pts := make([]Coordinate, 0, f.nPts)
+	for i := 0; i < f.nPts; i++ {
+		ang := startAng + float64(i)*angInc
+		pts = append(pts, NewXYCoordinate(xRadius*snappedCos(ang)+centreX, yRadius*snappedSin(ang)+centreY))
+	}
+	return f.finish(pts)
+}
+
+// Creates an elliptical arc polygon.
+// The polygon is formed from the specified arc of an ellipse
+// and the two radii connecting the endpoints to the centre of the ellipse.
+func (f *GeometricShapeFactory) CreateArcPolygon(startAng, angExtent float64) []Coordinate {
+	env := f.dim.getEnvelope()
+	xRadius := env.width() / 2
+	yRadius := env.height() / 2
+	centreX := env.minX + xRadius
+	centreY := env.minY + yRadius
+
+	angSize := angExtent
+	if angSize <= 0 || angSize > 2*math.Pi {
+		angSize = 2 * math.Pi
+	}
+	angInc := angSize / float64(f.nPts-1)
+
+	pts := make([]Coordinate, 0, f.nPts+2)
+	pts = append(pts, NewXYCoordinate(centreX, centreY))
+	for i := 0; i < f.nPts; i++ {
+		ang := startAng + float64(i)*angInc
+		pts = append(pts, NewXYCoordinate(xRadius*snappedCos(ang)+centreX, yRadius*snappedSin(ang)+centreY))
+	}
+	pts = append(pts, NewXYCoordinate(centreX, centreY))
+	return f.finish(pts)
+}
+
+// Computes the cosine of an angle, snapping results
+// very close to zero to exactly zero.
+func snappedCos(ang float64) float64 {
+	res := math.Cos(ang)
+	if math.Abs(res) < 5e-16 {
+		return 0
+	}
+	return res
+}
+
+// Computes the sine of an angle, snapping results
+// very close to zero to exactly zero.
+func snappedSin(ang float64) float64 {
+	res := math.Sin(ang)
+	if math.Abs(res) < 5e-16 {
+		return 0
+	}
+	return res
+}
@@ -0,0 +1,152 @@
+package geom
+
+import (
+	"errors"
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestCreateRectangle(t *testing.T) {
+	gsf := NewGeometricShapeFactory()
+	gsf.SetBase(NewXYCoordinate(10, 20))
+	gsf.SetWidth(4)
+	gsf.SetHeight(2)
+	gsf.SetNumPoints(4)
+	assert2.True(t, equalArrays(createLine(10, 20, 14, 20, 14, 22, 10, 22, 10, 20), gsf.CreateRectangle()))
+
+	gsf.SetNumPoints(8)
+	rect := gsf.CreateRectangle()
+	assert2.Equal(t, 9, len(rect))
+	assert2.True(t, rect[1].equals2D(&Coordinate{x: 12, y: 20}))
+	assert2.Equal(t, 8.0, signedRingArea(rect))
+}
+
+func TestCreateRectangleRotated(t *testing.T) {
+	gsf := NewGeometricShapeFactoryFromPrecisionModel(mustFixedPrecisionModel(1e6))
+	gsf.SetCentre(NewXYCoordinate(0, 0))
+	gsf.SetSize(2)
+	gsf.SetNumPoints(4)
+	gsf.SetRotation(math.Pi / 4)
+	rect := gsf.CreateRectangle()
+	assert2.True(t, rect[0].equals2D(&Coordinate{x: 0, y: -1.414214}))
+	assert2.InDelta(t, 4.0, signedRingArea(rect), 1e-5)
+}
+
+func TestCreateCircle(t *testing.T) {
+	gsf := NewGeometricShapeFactory()
+	gsf.SetCentre(NewXYCoordinate(5, 5))
+	gsf.SetSize(10)
+	gsf.SetNumPoints(64)
+	circle := gsf.CreateCircle()
+	assert2.Equal(t, 65, len(circle))
+	assert2.True(t, isRing(circle))
+	assert2.True(t, circle[0].equals2D(&Coordinate{x: 10, y: 5}))
+	assert2.True(t, circle[16].equals2D(&Coordinate{x: 5, y: 10}))
+	centre := NewXYCoordinate(5, 5)
+	for _, p := range circle {
+		assert2.InDelta(t, 5.0, p.distance(&centre), 1e-9)
+	}
+
+	gsf.SetEnvelope(NewEnvelope(0, 10, 0, 4))
+	ellipse := gsf.CreateEllipse()
+	env := envelope(ellipse)
+	assert2.True(t, env.Equals(NewEnvelope(0, 10, 0, 4)))
+}
+
+func TestCreateArc(t *testing.T) {
+	gsf := NewGeometricShapeFactory()
+	gsf.SetSize(2)
+	gsf.SetCentre(NewXYCoordinate(0, 0))
+	gsf.SetNumPoints(5)
+	arc := gsf.CreateArc(0, math.Pi/2)
+	assert2.Equal(t, 5, len(arc))
+	assert2.True(t, arc[0].equals2D(&Coordinate{x: 1, y: 0}))
+	assert2.True(t, arc[4].equals2D(&Coordinate{x: 0, y: 1}))
+
+	arcPolygon := gsf.CreateArcPolygon(0, math.Pi/2)
+	assert2.Equal(t, 7, len(arcPolygon))
+	assert2.True(t, isRing(arcPolygon))
+	assert2.True(t, arcPolygon[0].equals2D(&Coordinate{x: 0, y: 0}))
+	assert2.True(t, signedRingArea(arcPolygon) > 0 && signedRingArea(arcPolygon) < math.Pi/4)
+}
+
+func TestCreateSquircle(t *testing.T) {
+	gsf := NewGeometricShapeFactory()
+	gsf.SetCentre(NewXYCoordinate(0, 0))
+	gsf.SetSize(2)
+	gsf.SetNumPoints(80)
+	squircle := gsf.CreateSquircle()
+	assert2.Equal(t, 81, len(squircle))
+	assert2.True(t, isRing(squircle))
+	for _, p := range squircle {
+		assert2.InDelta(t, 1.0, math.Pow(p.x, 4)+math.Pow(p.y, 4), 1e-9)
+	}
+	assert2.True(t, squircle[0].equals2D(&Coordinate{x: 0, y: 1}))
+	// a squircle lies between the circle and the square
+	area := signedRingArea(squircle)
+	assert2.True(t, area > math.Pi && area < 4)
+}
+
+func TestCreateSuperellipse(t *testing.T) {
+	gsf := NewGeometricShapeFactory()
+	gsf.SetEnvelope(NewEnvelope(-2, 2, -1, 1))
+	gsf.SetNumPoints(40)
+	for _, p := range gsf.CreateSuperellipse(4) {
+		assert2.InDelta(t, 1.0, math.Pow(p.x/2, 4)+math.Pow(p.y, 4), 1e-9)
+	}
+	ellipse := gsf.CreateSuperellipse(2)
+	expected := gsf.CreateEllipse()
+	for i := range ellipse {
+		assert2.InDelta(t, expected[i].x, ellipse[i].x, 1e-9)
+		assert2.InDelta(t, expected[i].y, ellipse[i].y, 1e-9)
+	}
+}
+
+func TestCreateSineStar(t *testing.T) {
+	star, err := CreateSineStar(NewXYCoordinate(0, 0), 10, 80, 4, 0.5)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 81, len(star))
+	assert2.True(t, isRing(star))
+	origin := NewXYCoordinate(0, 0)
+	// the arms reach the full radius, and the troughs half of it
+	assert2.InDelta(t, 5.0, star[0].distance(&origin), 1e-9)
+	assert2.InDelta(t, 2.5, star[10].distance(&origin), 1e-9)
+}
+
+func TestInvalidNumPoints(t *testing.T) {
+	gsf := NewGeometricShapeFactory()
+	gsf.SetSize(2)
+	gsf.SetCentre(NewXYCoordinate(0, 0))
+	for _, nPts := range []int{-1, 0, 1, 2} {
+		assert2.True(t, errors.Is(gsf.SetNumPoints(nPts), ErrInvalidArgument), nPts)
+	}
+	// the shapes are still created with the previous number of points
+	assert2.Equal(t, 101, len(gsf.CreateCircle()))
+	assert2.Equal(t, 100, len(gsf.CreateArc(0, math.Pi)))
+	_, err := CreateSineStar(NewXYCoordinate(0, 0), 10, 0, 4, 0.5)
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+
+	assert2.Nil(t, gsf.SetNumPoints(3))
+	arc := gsf.CreateArc(0, math.Pi)
+	assert2.Equal(t, 3, len(arc))
+	assert2.True(t, arc[1].equals2D(&Coordinate{x: 0, y: 1}), "%v", arc)
+}
+
+func TestShapesAreCounterClockwise(t *testing.T) {
+	gsf := NewGeometricShapeFactory()
+	gsf.SetSize(10)
+	assert2.Nil(t, gsf.SetNumPoints(40))
+	for _, ring := range [][]Coordinate{
+		gsf.CreateRectangle(),
+		gsf.CreateCircle(),
+		gsf.CreateSquircle(),
+		gsf.CreateSuperellipse(3),
+		gsf.CreateArcPolygon(0, math.Pi),
+	} {
+		assert2.True(t, signedRingArea(ring) > 0, "%v", ring)
+	}
+	star := NewSineStarFactory()
+	star.SetSize(10)
+	assert2.True(t, signedRingArea(star.CreateSineStar()) > 0)
+}
@@ -0,0 +1,162 @@
+package geom
+
+// Reduces the precision of the coordinates of lines and polygons
+// to a new PrecisionModel, ensuring that the result is valid.
+//
+// By default the reduction is done by snap-rounding the linework to
+// the target precision grid, and rebuilding polygons from the noded rings.
+// The resulting polygons are topologically valid: rounding never causes
+// rings to self-intersect or cross each other.
+// Components which collapse to a lower dimension are removed.
+// A polygon may be split into several polygons, which is why the
+// polygonal methods return a list of polygons, each given as a list of
+// rings with the shell first.
+//
+// Alternatively, a pointwise reduction can be used,
+// which simply rounds each coordinate and removes repeated points.
+// This is faster, but the result may be invalid.
+// In pointwise mode, collapsed components can be kept
+// (padded to the minimum number of points) rather than removed.
+//
+// Snap-rounding requires a FIXED precision model;
+// for floating precision models the reduction is always pointwise.
+type GeometryPrecisionReducer struct {
+	targetPM        PrecisionModel
+	removeCollapsed bool
+	isPointwise     bool
+}
+
+// Creates a reducer for a target PrecisionModel,
+// which snap-rounds and removes collapsed components.
+func NewGeometryPrecisionReducer(pm PrecisionModel) GeometryPrecisionReducer {
+	return GeometryPrecisionReducer{
+		targetPM:        pm,
+		removeCollapsed: true,
+	}
+}
+
+// Reduces the precision of a polygon, given as a list of rings with the shell first,
+// ensuring that the result is valid.
+// A failure of the snap-rounding gives a TopologyError.
+func ReducePolygonPrecision(rings [][]Coordinate, pm PrecisionModel) ([][][]Coordinate, error) {
+	reducer := NewGeometryPrecisionReducer(pm)
+	return reducer.ReducePolygon(rings)
+}
+
+// Reduces the precision of a polygon, given as a list of rings with the shell first,
+// rounding each coordinate individually.
+// The result may be invalid.
+func ReducePolygonPrecisionPointwise(rings [][]Coordinate, pm PrecisionModel) ([][][]Coordinate, error) {
+	reducer := NewGeometryPrecisionReducer(pm)
+	reducer.SetPointwise(true)
+	return reducer.ReducePolygon(rings)
+}
+
+// Sets whether the reduction will result in collapsed components
+// being removed completely, or simply being collapsed to an (invalid)
+// component of the same type.
+// This only has an effect in pointwise mode and for lines;
+// snap-rounded polygons always have their collapsed components removed.
+// The default is to remove collapsed components.
+func (r *GeometryPrecisionReducer) SetRemoveCollapsedComponents(removeCollapsed bool) {
+	r.removeCollapsed = removeCollapsed
+}
+
+// Sets whether the precision reduction will be done
+// in pointwise fashion only.
+// Pointwise precision reduction reduces the precision
+// of the individual coordinates only, but does
+// not attempt to recreate valid topology.
+// This is only relevant for polygons.
+func (r *GeometryPrecisionReducer) SetPointwise(isPointwise bool) {
+	r.isPointwise = isPointwise
+}
+
+func (r *GeometryPrecisionReducer) isSnapRounding() bool {
+	return !r.isPointwise && r.targetPM.modelType == FIXED
+}
+
+// Reduces the precision of a line.
+// Returns nil if the line collapses to a point and collapsed
+// components are removed.
+func (r *GeometryPrecisionReducer) ReduceLine(pts []Coordinate) []Coordinate {
+	var reduced []Coordinate
+	if r.isSnapRounding() {
+		rounder := newSnapRounder(r.targetPM)
+		reduced = rounder.snapRound([][]Coordinate{pts})[0]
+	} else {
+		reduced = r.reducePointwise(pts)
+	}
+	return r.checkCollapse(reduced, 2)
+}
+
+// Reduces the precision of a polygon, given as a list of rings with the shell first.
+// Returns the resulting polygons, each as a list of rings with the shell first.
+// The result is empty if the polygon collapses and collapsed components are removed.
+// A failure of the snap-rounding gives a TopologyError.
+func (r *GeometryPrecisionReducer) ReducePolygon(rings [][]Coordinate) ([][][]Coordinate, error) {
+	if len(rings) == 0 {
+		return [][][]Coordinate{}, nil
+	}
+	if r.isSnapRounding() {
+		return r.reducePolygonSnapRounded(rings)
+	}
+	shell := r.checkCollapse(r.reducePointwise(rings[0]), 4)
+	if shell == nil {
+		return [][][]Coordinate{}, nil
+	}
+	polygon := [][]Coordinate{shell}
+	for _, hole := range rings[1:] {
+		if reduced := r.checkCollapse(r.reducePointwise(hole), 4); reduced != nil {
+			polygon = append(polygon, reduced)
+		}
+	}
+	return [][][]Coordinate{polygon}, nil
+}
+
+func (r *GeometryPrecisionReducer) reducePolygonSnapRounded(rings [][]Coordinate) ([][][]Coordinate, error) {
+	// orient the rings so the polygon interior is on the left
+	oriented := make([][]Coordinate, len(rings))
+	for i, ring := range rings {
+		oriented[i] = copyDeepCoordinateArray(ring)
+		isShell := i == 0
+		if (signedRingArea(oriented[i]) > 0) != isShell {
+			reverse(oriented[i])
+		}
+	}
+	rounder := newSnapRounder(r.targetPM)
+	polygons, err := buildPolygons(rounder.snapRound(oriented))
+	if err != nil {
+		if topoErr, ok := err.(*TopologyError); ok {
+			topoErr.addInputPolygon(rings)
+		}
+		return nil, err
+	}
+	return polygons, nil
+}
+
+// Rounds each coordinate and removes repeated points.
+func (r *GeometryPrecisionReducer) reducePointwise(pts []Coordinate) []Coordinate {
+	reduced := copyDeepCoordinateArray(pts)
+	for i := range reduced {
+		r.targetPM.makePreciseCoordinate(&reduced[i])
+	}
+	return removeRepeatedPoints(reduced)
+}
+
+// Checks whether a reduced component has collapsed below
+// the minimum number of points.
+// Collapsed components are removed (nil is returned),
+// or padded by repeating the last point.
+func (r *GeometryPrecisionReducer) checkCollapse(pts []Coordinate, minLength int) []Coordinate {
+	if len(pts) >= minLength {
+		return pts
+	}
+	if r.removeCollapsed || len(pts) == 0 {
+		return nil
+	}
+	for len(pts) < minLength {
+		pts = append(pts, pts[len(pts)-1])
+	}
+	return pts
+}
@@ -0,0 +1,125 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestReducePolygonSpikeRemoved(t *testing.T) {
+	pm := mustFixedPrecisionModel(1)
+	rings := [][]Coordinate{createLine(0, 0, 10, 0, 10, 10, 5, 10, 5.1, 15, 4.9, 10, 0, 10, 0, 0)}
+
+	pointwise, err := ReducePolygonPrecisionPointwise(rings, pm)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 1, len(pointwise))
+	assert2.True(t, indexOf(NewXYCoordinate(5, 15), pointwise[0][0]) >= 0)
+
+	reduced, err := ReducePolygonPrecision(rings, pm)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 1, len(reduced))
+	assert2.Equal(t, 1, len(reduced[0]))
+	assert2.Equal(t, -1, indexOf(NewXYCoordinate(5, 15), reduced[0][0]))
+	assert2.Equal(t, 100.0, signedRingArea(reduced[0][0]))
+}
+
+func TestReducePolygonHoleCollapsesOntoShell(t *testing.T) {
+	pm := mustFixedPrecisionModel(1)
+	rings := [][]Coordinate{
+		createLine(0, 0, 10, 0, 10, 10, 0, 10, 0, 0),
+		createLine(1, 0.4, 1, 3, 3, 3, 3, 0.4, 1, 0.4),
+	}
+	reduced, err := ReducePolygonPrecision(rings, pm)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 1, len(reduced))
+	// the hole becomes a notch in the shell
+	assert2.Equal(t, 1, len(reduced[0]))
+	assert2.Equal(t, 94.0, signedRingArea(reduced[0][0]))
+}
+
+func TestReducePolygonKeepsHole(t *testing.T) {
+	pm := mustFixedPrecisionModel(1)
+	rings := [][]Coordinate{
+		createLine(0, 0, 0, 10, 10, 10, 10, 0, 0, 0),
+		createLine(2.2, 2.2, 4.1, 2.2, 4.1, 3.9, 2.2, 3.9, 2.2, 2.2),
+	}
+	reduced, err := ReducePolygonPrecision(rings, pm)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 1, len(reduced))
+	assert2.Equal(t, 2, len(reduced[0]))
+	// shell counter-clockwise and hole clockwise
+	assert2.Equal(t, 100.0, signedRingArea(reduced[0][0]))
+	assert2.Equal(t, -4.0, signedRingArea(reduced[0][1]))
+}
+
+func TestReducePolygonSplit(t *testing.T) {
+	pm := mustFixedPrecisionModel(1)
+	// an hourglass whose waist collapses to a point
+	rings := [][]Coordinate{createLine(0, 0, 4, 0, 2.1, 2, 4, 4, 0, 4, 1.9, 2, 0, 0)}
+	reduced, err := ReducePolygonPrecision(rings, pm)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 2, len(reduced))
+	for _, polygon := range reduced {
+		assert2.Equal(t, 4.0, signedRingArea(polygon[0]))
+	}
+}
+
+func TestReducePolygonCollapsed(t *testing.T) {
+	pm := mustFixedPrecisionModel(1)
+	rings := [][]Coordinate{createLine(0.1, 0.1, 0.3, 0.1, 0.2, 0.3, 0.1, 0.1)}
+	reduced, err := ReducePolygonPrecision(rings, pm)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, len(reduced))
+	reduced, err = ReducePolygonPrecisionPointwise(rings, pm)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 0, len(reduced))
+
+	reducer := NewGeometryPrecisionReducer(pm)
+	reducer.SetPointwise(true)
+	reducer.SetRemoveCollapsedComponents(false)
+	reduced, err = reducer.ReducePolygon(rings)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 1, len(reduced))
+	assert2.True(t, equalArrays(createLine(0, 0, 0, 0, 0, 0, 0, 0), reduced[0][0]))
+}
+
+func TestReduceLine(t *testing.T) {
+	pm := mustFixedPrecisionModel(1)
+	// the end point is rounded onto the first segment, which is noded there
+	line := createLine(0, 0, 0.4, 0.1, 4, 0, 4, 1, 2.1, 0.3)
+	reducer := NewGeometryPrecisionReducer(pm)
+	assert2.True(t, equalArrays(createLine(0, 0, 2, 0, 4, 0, 4, 1, 2, 0), reducer.ReduceLine(line)))
+	reducer.SetPointwise(true)
+	assert2.True(t, equalArrays(createLine(0, 0, 4, 0, 4, 1, 2, 0), reducer.ReduceLine(line)))
+}
+
+func TestReduceLineCollapsed(t *testing.T) {
+	pm := mustFixedPrecisionModel(1)
+	line := createLine(0.1, 0.1, 0.2, 0.2)
+	reducer := NewGeometryPrecisionReducer(pm)
+	assert2.Nil(t, reducer.ReduceLine(line))
+	reducer.SetRemoveCollapsedComponents(false)
+	assert2.True(t, equalArrays(createLine(0, 0, 0, 0), reducer.ReduceLine(line)))
+}
+
+func TestReducePolygonFineGrid(t *testing.T) {
+	pm := mustFixedPrecisionModel(1e7)
+	rings := [][]Coordinate{createLine(
+		4.12345671, 52.00000001, 4.12345689, 52.00000001,
+		4.12345681, 52.00000031, 4.12345671, 52.00000001)}
+	reduced, err := ReducePolygonPrecision(rings, pm)
+	assert2.Nil(t, err)
+	assert2.Equal(t, 1, len(reduced))
+	for _, p := range reduced[0][0] {
+		assert2.Equal(t, math.Round(p.x*1e7)/1e7, p.x)
+		assert2.Equal(t, math.Round(p.y*1e7)/1e7, p.y)
+	}
+}
+
+func TestReducePolygonFloating(t *testing.T) {
+	rings := [][]Coordinate{createLine(0.1, 0.1, 0.3, 0.1, 0.2, 0.3, 0.1, 0.1)}
+	reduced, err := ReducePolygonPrecision(rings, NewDefaultPrecisionModel())
+	assert2.Nil(t, err)
+	assert2.Equal(t, 1, len(reduced))
+	assert2.True(t, equalArrays(rings[0], reduced[0][0]))
+}
@@ -0,0 +1,114 @@
+package geom
+
+import "math"
+
+// A spatial index of envelopes, which buckets them by the cells
+// of a uniform grid they overlap.
+// Items are identified by the order in which they were inserted,
+// and may be inserted between queries.
+//
+// The cell size is chosen so that items spread evenly over the extent
+// of the index share a cell with few others.
+// Items and queries which span more cells than there are items
+// are not bucketed: such items are visited by every query,
+// and such queries scan all the items.
+type gridIndex struct {
+	originX  float64
+	originY  float64
+	cellSize float64
+	size     int
+	cells    map[[2]int][]int
+	large    []int
+	envs     []Envelope
+	// the query during which each item was last visited,
+	// so that items spanning several cells are visited once per query
+	visited []int
+	queryId int
+}
+
+// Creates an index for about size items lying in an extent.
+func newGridIndex(extent Envelope, size int) gridIndex {
+	index := gridIndex{
+		cellSize: 1,
+		size:     size,
+		cells:    make(map[[2]int][]int),
+	}
+	if extent.isNull() {
+		return index
+	}
+	n := math.Max(1, float64(size))
+	cellSize := math.Max(math.Sqrt(extent.area()/n), extent.maxExtent()/n)
+	if cellSize > 0 {
+		index.cellSize = cellSize
+	}
+	index.originX = extent.minX
+	index.originY = extent.minY
+	return index
+}
+
+// Gets the range of cells overlapped by an envelope,
+// and whether it is small enough to be visited cell by cell.
+func (g *gridIndex) cellRange(env Envelope) (ix0, iy0, ix1, iy1 int, ok bool) {
+	x0 := math.Floor((env.minX - g.originX) / g.cellSize)
+	y0 := math.Floor((env.minY - g.originY) / g.cellSize)
+	x1 := math.Floor((env.maxX - g.originX) / g.cellSize)
+	y1 := math.Floor((env.maxY - g.originY) / g.cellSize)
+	if (x1-x0+1)*(y1-y0+1) > math.Max(1, math.Max(float64(g.size), float64(len(g.envs)))) {
+		return 0, 0, 0, 0, false
+	}
+	return int(x0), int(y0), int(x1), int(y1), true
+}
+
+// Inserts an envelope and returns its item number.
+func (g *gridIndex) insert(env Envelope) int {
+	item := len(g.envs)
+	g.envs = append(g.envs, env)
+	g.visited = append(g.visited, 0)
+	ix0, iy0, ix1, iy1, ok := g.cellRange(env)
+	if !ok {
+		g.large = append(g.large, item)
+		return item
+	}
+	for ix := ix0; ix <= ix1; ix++ {
+		for iy := iy0; iy <= iy1; iy++ {
+			key := [2]int{ix, iy}
+			g.cells[key] = append(g.cells[key], item)
+		}
+	}
+	return item
+}
+
+// Calls visit for every item whose envelope intersects the query envelope.
+func (g *gridIndex) query(env Envelope, visit func(item int)) {
+	if env.isNull() {
+		return
+	}
+	g.queryId++
+	ix0, iy0, ix1, iy1, ok := g.cellRange(env)
+	if !ok {
+		for item := range g.envs {
+			if env.intersectsEnvelope(g.envs[item]) {
+				visit(item)
+			}
+		}
+		return
+	}
+	g.visitItems(g.large, env, visit)
+	for ix := ix0; ix <= ix1; ix++ {
+		for iy := iy0; iy <= iy1; iy++ {
+			g.visitItems(g.cells[[2]int{ix, iy}], env, visit)
+		}
+	}
+}
+
+func (g *gridIndex) visitItems(items []int, env Envelope, visit func(item int)) {
+	for _, item := range items {
+		if g.visited[item] == g.queryId {
+			continue
+		}
+		g.visited[item] = g.queryId
+		if env.intersectsEnvelope(g.envs[item]) {
+			visit(item)
+		}
+	}
+}
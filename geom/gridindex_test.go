@@ -0,0 +1,36 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func TestGridIndexQuery(t *testing.T) {
+	index := newGridIndex(NewEnvelope(0, 10, 0, 10), 4)
+	index.insert(NewPointEnvelope(NewXYCoordinate(1, 1)))
+	index.insert(NewPointEnvelope(NewXYCoordinate(9, 9)))
+	index.insert(NewEnvelope(0, 10, 4, 6))
+	// an item outside of the extent of the index
+	index.insert(NewPointEnvelope(NewXYCoordinate(-5, 20)))
+	assert2.Equal(t, []int{0, 2}, queryGridIndex(&index, NewEnvelope(0, 2, 0, 5)))
+	assert2.Equal(t, []int{2}, queryGridIndex(&index, NewEnvelope(4, 5, 4, 5)))
+	assert2.Equal(t, []int{3}, queryGridIndex(&index, NewEnvelope(-6, -4, 19, 21)))
+	assert2.Equal(t, []int{0, 1, 2, 3}, queryGridIndex(&index, NewEnvelope(-100, 100, -100, 100)))
+	assert2.Empty(t, queryGridIndex(&index, NewEmptyEnvelope()))
+}
+
+func TestGridIndexEmptyExtent(t *testing.T) {
+	index := newGridIndex(NewEmptyEnvelope(), 0)
+	index.insert(NewPointEnvelope(NewXYCoordinate(3, 4)))
+	assert2.Equal(t, []int{0}, queryGridIndex(&index, NewPointEnvelope(NewXYCoordinate(3, 4))))
+}
+
+func queryGridIndex(index *gridIndex, env Envelope) []int {
+	var items []int
+	index.query(env, func(item int) {
+		items = append(items, item)
+	})
+	sort.Ints(items)
+	return items
+}
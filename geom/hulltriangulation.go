@@ -0,0 +1,333 @@
+package geom
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// A triangle of a hull triangulation, linked to the triangles
+// adjacent to each of its edges.
+// Edge i runs from vertex i to vertex i+1 (modulo 3).
+type hullTri struct {
+	p       [3]Coordinate
+	adj     [3]*hullTri
+	removed bool
+	// a fixed triangle is never removed from the hull
+	isFixed bool
+	// the priority of the triangle in a removal queue
+	size float64
+}
+
+// Gets the number of live triangles adjacent to this one.
+func (t *hullTri) numAdjacent() int {
+	n := 0
+	for _, a := range t.adj {
+		if a != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Tests whether the triangle has an edge on the boundary of the hull.
+func (t *hullTri) isBorder() bool {
+	return t.numAdjacent() < 3
+}
+
+// Gets the length of edge i.
+func (t *hullTri) edgeLength(i int) float64 {
+	return t.p[i].distance(&t.p[(i+1)%3])
+}
+
+// Gets the length of the longest edge of the triangle.
+func (t *hullTri) longestEdgeLength() float64 {
+	maxLen := 0.0
+	for i := 0; i < 3; i++ {
+		if l := t.edgeLength(i); l > maxLen {
+			maxLen = l
+		}
+	}
+	return maxLen
+}
+
+// Gets the length of the longest edge of the triangle
+// which lies on the boundary of the hull, or 0 if there is none.
+func (t *hullTri) boundaryEdgeLength() float64 {
+	maxLen := 0.0
+	for i := 0; i < 3; i++ {
+		if t.adj[i] == nil {
+			if l := t.edgeLength(i); l > maxLen {
+				maxLen = l
+			}
+		}
+	}
+	return maxLen
+}
+
+// Gets the index of the first boundary edge, or -1 if there is none.
+func (t *hullTri) boundaryIndex() int {
+	for i := 0; i < 3; i++ {
+		if t.adj[i] == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// A max-priority queue of triangles, ordered by size.
+type hullTriQueue []*hullTri
+
+func (q hullTriQueue) Len() int           { return len(q) }
+func (q hullTriQueue) Less(i, j int) bool { return q[i].size > q[j].size }
+func (q hullTriQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *hullTriQueue) Push(x interface{}) {
+	*q = append(*q, x.(*hullTri))
+}
+
+func (q *hullTriQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	tri := old[n-1]
+	*q = old[:n-1]
+	return tri
+}
+
+// A key for a directed triangle edge.
+type hullEdgeKey struct {
+	from CoordinateKey
+	to   CoordinateKey
+}
+
+// The triangles of a concave hull under construction.
+// Triangles are removed from the border inwards, in such a way that
+// the remaining triangles always form a single polygon,
+// possibly with holes, containing all the triangle vertices.
+type hullTriangulation struct {
+	tris       []*hullTri
+	vertexTris map[CoordinateKey][]*hullTri
+}
+
+// Creates a hull triangulation from triangles given as
+// rings of Coordinate(s) in counter-clockwise order.
+func newHullTriangulation(triangles [][]Coordinate) hullTriangulation {
+	h := hullTriangulation{
+		vertexTris: make(map[CoordinateKey][]*hullTri),
+	}
+	edges := make(map[hullEdgeKey]*hullTri)
+	for _, ring := range triangles {
+		tri := &hullTri{p: [3]Coordinate{ring[0], ring[1], ring[2]}}
+		h.tris = append(h.tris, tri)
+		for i := 0; i < 3; i++ {
+			key := CoordinateKey{tri.p[i].x, tri.p[i].y}
+			h.vertexTris[key] = append(h.vertexTris[key], tri)
+			edges[tri.edgeKey(i)] = tri
+		}
+	}
+	for _, tri := range h.tris {
+		for i := 0; i < 3; i++ {
+			key := tri.edgeKey(i)
+			tri.adj[i] = edges[hullEdgeKey{key.to, key.from}]
+		}
+	}
+	return h
+}
+
+func (t *hullTri) edgeKey(i int) hullEdgeKey {
+	p0 := t.p[i]
+	p1 := t.p[(i+1)%3]
+	return hullEdgeKey{CoordinateKey{p0.x, p0.y}, CoordinateKey{p1.x, p1.y}}
+}
+
+// Computes the maximum edge length corresponding to a ratio
+// between the shortest and the longest edge of the removable triangles.
+func (h *hullTriangulation) computeTargetEdgeLength(edgeLengthRatio float64) float64 {
+	if edgeLengthRatio == 0 {
+		return 0
+	}
+	maxEdgeLen := -1.0
+	minEdgeLen := -1.0
+	for _, tri := range h.tris {
+		if tri.isFixed {
+			continue
+		}
+		for i := 0; i < 3; i++ {
+			l := tri.edgeLength(i)
+			if l > maxEdgeLen {
+				maxEdgeLen = l
+			}
+			if minEdgeLen < 0 || l < minEdgeLen {
+				minEdgeLen = l
+			}
+		}
+	}
+	if edgeLengthRatio == 1 {
+		return 2 * maxEdgeLen
+	}
+	return edgeLengthRatio*(maxEdgeLen-minEdgeLen) + minEdgeLen
+}
+
+// Tests whether a vertex is on the boundary of the hull.
+func (h *hullTriangulation) isBoundaryVertex(v Coordinate) bool {
+	for _, tri := range h.vertexTris[CoordinateKey{v.x, v.y}] {
+		if tri.removed {
+			continue
+		}
+		for i := 0; i < 3; i++ {
+			if tri.adj[i] != nil {
+				continue
+			}
+			if v.equals2D(&tri.p[i]) || v.equals2D(&tri.p[(i+1)%3]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Tests whether a triangle touches the boundary of the hull at a vertex.
+func (h *hullTriangulation) hasBoundaryTouch(tri *hullTri) bool {
+	for i := 0; i < 3; i++ {
+		if h.isBoundaryVertex(tri.p[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tests whether a triangle can be removed without splitting the hull
+// or dropping a vertex: it must have exactly one boundary edge,
+// and the vertex opposite that edge must not be on the boundary.
+func (h *hullTriangulation) isRemovable(tri *hullTri, maxEdgeLength float64) bool {
+	if tri.removed || tri.isFixed || tri.numAdjacent() != 2 {
+		return false
+	}
+	i := tri.boundaryIndex()
+	if tri.edgeLength(i) <= maxEdgeLength {
+		return false
+	}
+	return !h.isBoundaryVertex(tri.p[(i+2)%3])
+}
+
+// Removes a triangle, unlinking it from its neighbours.
+func (h *hullTriangulation) remove(tri *hullTri, queue *hullTriQueue) {
+	tri.removed = true
+	for i, a := range tri.adj {
+		if a == nil {
+			continue
+		}
+		for j := 0; j < 3; j++ {
+			if a.adj[j] == tri {
+				a.adj[j] = nil
+			}
+		}
+		tri.adj[i] = nil
+		a.size = a.boundaryEdgeLength()
+		heap.Push(queue, a)
+	}
+}
+
+// Removes border triangles with a boundary edge longer than
+// the maximum edge length, largest first.
+func (h *hullTriangulation) erodeBorder(maxEdgeLength float64) {
+	queue := &hullTriQueue{}
+	for _, tri := range h.tris {
+		if tri.isBorder() {
+			tri.size = tri.boundaryEdgeLength()
+			heap.Push(queue, tri)
+		}
+	}
+	h.drain(queue, nil, maxEdgeLength)
+}
+
+// Removes triangles in the queue which are removable,
+// adding their neighbours to the queue in turn.
+// The seed triangle is removed unconditionally.
+func (h *hullTriangulation) drain(queue *hullTriQueue, seed *hullTri, maxEdgeLength float64) {
+	for queue.Len() > 0 {
+		tri := heap.Pop(queue).(*hullTri)
+		if tri == seed || h.isRemovable(tri, maxEdgeLength) {
+			h.remove(tri, queue)
+		}
+	}
+}
+
+// Creates holes in the hull from interior triangles with an edge
+// longer than the maximum edge length.
+// Holes do not touch the outer boundary or each other.
+func (h *hullTriangulation) removeHoles(maxEdgeLength float64) {
+	var candidates []*hullTri
+	for _, tri := range h.tris {
+		if tri.removed || tri.isFixed || tri.isBorder() || tri.longestEdgeLength() <= maxEdgeLength {
+			continue
+		}
+		if !h.hasBoundaryTouch(tri) {
+			tri.size = tri.longestEdgeLength()
+			candidates = append(candidates, tri)
+		}
+	}
+	// remove largest first
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].size > candidates[j].size
+	})
+	for _, tri := range candidates {
+		if tri.removed || tri.isBorder() || h.hasBoundaryTouch(tri) {
+			continue
+		}
+		queue := &hullTriQueue{tri}
+		h.drain(queue, tri, maxEdgeLength)
+	}
+}
+
+// Extracts the boundary rings of the remaining triangles.
+// The shell is in counter-clockwise order and the holes are in clockwise order.
+// Each ring starts at its smallest vertex.
+func (h *hullTriangulation) toRings() ([]Coordinate, [][]Coordinate) {
+	next := make(map[CoordinateKey]Coordinate)
+	var starts []Coordinate
+	for _, tri := range h.tris {
+		if tri.removed {
+			continue
+		}
+		for i := 0; i < 3; i++ {
+			if tri.adj[i] == nil {
+				from := tri.p[i]
+				next[CoordinateKey{from.x, from.y}] = tri.p[(i+1)%3]
+				starts = append(starts, from)
+			}
+		}
+	}
+	shell := []Coordinate{}
+	var holes [][]Coordinate
+	visited := make(map[CoordinateKey]bool)
+	for _, start := range starts {
+		if visited[CoordinateKey{start.x, start.y}] {
+			continue
+		}
+		ring := []Coordinate{start}
+		minIndex := 0
+		curr := start
+		for {
+			visited[CoordinateKey{curr.x, curr.y}] = true
+			curr = next[CoordinateKey{curr.x, curr.y}]
+			if curr.equals2D(&start) {
+				break
+			}
+			if curr.compareTo(ring[minIndex]) < 0 {
+				minIndex = len(ring)
+			}
+			ring = append(ring, curr)
+		}
+		ring = append(ring, start)
+		// the traced ring is closed and minIndex lies within it, so this cannot fail
+		_ = scroll(ring, minIndex, true)
+		if signedRingArea(ring) > 0 {
+			if len(shell) == 0 || signedRingArea(ring) > signedRingArea(shell) {
+				shell = ring
+			}
+		} else {
+			holes = append(holes, ring)
+		}
+	}
+	return shell, holes
+}
@@ -0,0 +1,99 @@
+package geom
+
+// Computes a Delaunay Triangulation of a set of Coordinate(s)
+// using the Incremental Algorithm for Delaunay Triangulation.
+// This algorithm inserts each site into the triangulation,
+// and then swaps edges until the Delaunay condition is restored.
+type incrementalDelaunayTriangulator struct {
+	subdiv *QuadEdgeSubdivision
+}
+
+// Creates a new triangulator using the given QuadEdgeSubdivision.
+// The triangulator uses the tolerance of the supplied subdivision.
+func newIncrementalDelaunayTriangulator(subdiv *QuadEdgeSubdivision) incrementalDelaunayTriangulator {
+	return incrementalDelaunayTriangulator{
+		subdiv: subdiv,
+	}
+}
+
+// Inserts all sites in a collection. The inserted vertices MUST be
+// unique up to the provided tolerance value. (i.e. no two vertices should be
+// closer than the provided tolerance value). They do not have to be rounded
+// to the tolerance grid, however.
+func (t *incrementalDelaunayTriangulator) insertSites(vertices []Coordinate) error {
+	for _, v := range vertices {
+		if _, err := t.insertSite(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Inserts a site and returns the vertex which represents it in the
+// subdivision. This is the site itself, unless it lies within the
+// tolerance of an existing vertex.
+func (t *incrementalDelaunayTriangulator) insertVertex(v Coordinate) (Coordinate, error) {
+	e, err := t.insertSite(v)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	orig := e.orig()
+	if v.equals2DWithTolerance(&orig, t.subdiv.tolerance) {
+		return orig, nil
+	}
+	return e.dest(), nil
+}
+
+// Inserts a new point into a subdivision representing a Delaunay
+// triangulation, and fixes the affected edges so that the result is still a
+// Delaunay triangulation.
+// Returns a quadedge containing the inserted vertex.
+func (t *incrementalDelaunayTriangulator) insertSite(v Coordinate) (*QuadEdge, error) {
+	// This code is based on Guibas and Stolfi (1985), with minor modifications
+	// and a bug fix from Dani Lischinski (Graphic Gems 1993).
+	// (The modification I believe is the test for the inserted site falling exactly on an
+	// existing edge. Without this test zero-width triangles have been observed
+	// to be created)
+	e, err := t.subdiv.locate(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.subdiv.isVertexOfEdge(e, v) {
+		// point is already in subdivision.
+		return e, nil
+	} else if t.subdiv.isOnEdge(e, v) {
+		// the point lies exactly on an edge, so delete the edge
+		// (it will be replaced by a pair of edges which have the point as a vertex)
+		e = e.oPrev()
+		t.subdiv.delete(e.oNext())
+	}
+
+	// Connect the new point to the vertices of the containing
+	// triangle (or quadrilateral, if the new point fell on an
+	// existing edge.)
+	base := t.subdiv.makeEdge(e.orig(), v)
+	spliceQuadEdges(base, e)
+	startEdge := base
+	for {
+		base = t.subdiv.connect(e, base.sym())
+		e = base.oPrev()
+		if e.lNext() == startEdge {
+			break
+		}
+	}
+
+	// Examine suspect edges to ensure that the Delaunay condition
+	// is satisfied.
+	for {
+		tEdge := e.oPrev()
+		if isRightOf(tEdge.dest(), e) && isInCircle(e.orig(), tEdge.dest(), e.dest(), v) {
+			swapQuadEdge(e)
+			e = e.oPrev()
+		} else if e.oNext() == startEdge {
+			return base, nil
+		} else {
+			e = e.oNext().lPrev()
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package geom
+
+import "math"
+
+// Supports linear referencing along a line
+// using the length along the line as the index.
+// Negative length values are taken as measured in the reverse direction
+// from the end of the line.
+// Out-of-range index values are handled by clamping
+// them to the valid range of values.
+type LengthIndexedLine struct {
+	pts []Coordinate
+}
+
+// Constructs an object which allows a line, given as a Coordinate array,
+// to be linearly referenced using length as an index.
+func NewLengthIndexedLine(pts []Coordinate) LengthIndexedLine {
+	return LengthIndexedLine{
+		pts: pts,
+	}
+}
+
+// Computes the Coordinate for the point on the line at the given index.
+// If the index is out of range the first or last point on the
+// line will be returned.
+// The Z ordinate of the computed point will be interpolated from
+// the Z ordinates of the line segment containing it, if they exist.
+func (l *LengthIndexedLine) ExtractPoint(index float64) Coordinate {
+	loc := locationOfLength(l.pts, index)
+	return loc.getCoordinate(l.pts)
+}
+
+// Computes the Coordinate for the point on the line at the given index,
+// offset by the given distance.
+// If the index is out of range the first or last point on the
+// line will be returned.
+// The computed point is offset to the left of the line if the offset distance is
+// positive, to the right if negative.
+func (l *LengthIndexedLine) ExtractPointWithOffset(index, offsetDistance float64) Coordinate {
+	loc := locationOfLength(l.pts, index)
+	indexed := NewLocationIndexedLine(l.pts)
+	return indexed.ExtractPointWithOffset(loc, offsetDistance)
+}
+
+// Computes the line between two indices.
+// If the start index is after the end index,
+// the computed line will be reversed.
+func (l *LengthIndexedLine) ExtractLine(startIndex, endIndex float64) []Coordinate {
+	startLoc := locationOfLength(l.pts, l.ClampIndex(startIndex))
+	endLoc := locationOfLength(l.pts, l.ClampIndex(endIndex))
+	return extractLineByLocation(l.pts, startLoc, endLoc)
+}
+
+// Computes the minimum index for a point on the line.
+// If the line is not simple (i.e. loops back on itself)
+// a single point may have more than one possible index.
+// In this case, the smallest index is returned.
+//
+// The supplied point does not necessarily have to lie precisely
+// on the line, but if it is far from the line the accuracy and
+// performance of this function is not guaranteed.
+// Use Project to compute a guaranteed result for points
+// which may be far from the line.
+func (l *LengthIndexedLine) IndexOf(pt Coordinate) float64 {
+	return lengthOfLocation(l.pts, locationOfPoint(l.pts, pt, nil))
+}
+
+// Finds the index for a point on the line
+// which is greater than the given index.
+// If no such index exists, returns minIndex.
+// This method can be used to determine all indexes for
+// a point which occurs more than once on a non-simple line.
+// It can also be used to disambiguate cases where the given point lies
+// slightly off the line and is equidistant from two different
+// points on the line.
+func (l *LengthIndexedLine) IndexOfAfter(pt Coordinate, minIndex float64) float64 {
+	if minIndex < 0.0 {
+		return l.IndexOf(pt)
+	}
+	endIndex := l.GetEndIndex()
+	if endIndex < minIndex {
+		return endIndex
+	}
+	minLoc := locationOfLength(l.pts, minIndex)
+	closestAfter := lengthOfLocation(l.pts, locationOfPoint(l.pts, pt, &minLoc))
+	return math.Max(closestAfter, minIndex)
+}
+
+// Computes the index for the closest point on the line to the given point.
+// If more than one point has the closest distance the first one along the line
+// is returned.
+// (The point does not necessarily have to lie precisely on the line.)
+func (l *LengthIndexedLine) Project(pt Coordinate) float64 {
+	return lengthOfLocation(l.pts, locationOfPoint(l.pts, pt, nil))
+}
+
+// Returns the index of the start of the line.
+func (l *LengthIndexedLine) GetStartIndex() float64 {
+	return 0.0
+}
+
+// Returns the index of the end of the line.
+func (l *LengthIndexedLine) GetEndIndex() float64 {
+	return lengthOfLine(l.pts)
+}
+
+// Tests whether an index is in the valid index range for the line.
+func (l *LengthIndexedLine) IsValidIndex(index float64) bool {
+	return index >= l.GetStartIndex() && index <= l.GetEndIndex()
+}
+
+// Computes a valid index for this line
+// by clamping the given index to the valid range of index values.
+func (l *LengthIndexedLine) ClampIndex(index float64) float64 {
+	posIndex := l.positiveIndex(index)
+	startIndex := l.GetStartIndex()
+	if posIndex < startIndex {
+		return startIndex
+	}
+	endIndex := l.GetEndIndex()
+	if posIndex > endIndex {
+		return endIndex
+	}
+	return posIndex
+}
+
+func (l *LengthIndexedLine) positiveIndex(index float64) float64 {
+	if index >= 0.0 {
+		return index
+	}
+	return lengthOfLine(l.pts) + index
+}
+
+// Computes the length of a line given as a Coordinate array.
+func lengthOfLine(pts []Coordinate) float64 {
+	result := 0.0
+	for i := 1; i < len(pts); i++ {
+		result += pts[i-1].distance(&pts[i])
+	}
+	return result
+}
+
+// Computes the LinearLocation for a given length along a line.
+// Negative lengths are measured in reverse from the end of the line.
+// Out-of-range values are clamped.
+func locationOfLength(pts []Coordinate, index float64) LinearLocation {
+	forwardLength := index
+	if index < 0.0 {
+		forwardLength = lengthOfLine(pts) + index
+	}
+	if forwardLength <= 0.0 {
+		return NewDefaultLinearLocation()
+	}
+	totalLength := 0.0
+	for i := 0; i < len(pts)-1; i++ {
+		segLen := pts[i].distance(&pts[i+1])
+		if totalLength+segLen > forwardLength {
+			frac := (forwardLength - totalLength) / segLen
+			return NewLinearLocation(i, frac)
+		}
+		totalLength += segLen
+	}
+	// length is longer than line - return end location
+	return getEndLocation(pts)
+}
+
+// Computes the length along a line of a given LinearLocation.
+func lengthOfLocation(pts []Coordinate, loc LinearLocation) float64 {
+	totalLength := 0.0
+	for i := 0; i < len(pts)-1; i++ {
+		if i == loc.segmentIndex {
+			return totalLength + loc.segmentFraction*pts[i].distance(&pts[i+1])
+		}
+		totalLength += pts[i].distance(&pts[i+1])
+	}
+	return totalLength
+}
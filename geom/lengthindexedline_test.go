@@ -0,0 +1,120 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestExtractLineBeyondRange(t *testing.T) {
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 10))
+	checkLine(createLine(0, 0, 10, 10), line.ExtractLine(-100, 100), t)
+}
+
+func TestExtractLineReverse(t *testing.T) {
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 0))
+	checkLine(createLine(9, 0, 1, 0), line.ExtractLine(9, 1), t)
+}
+
+func TestExtractLineAcrossVertices(t *testing.T) {
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 0, 10, 10, 20, 10))
+	checkLine(createLine(5, 0, 10, 0, 10, 10, 15, 10), line.ExtractLine(5, 25), t)
+	checkLine(createLine(10, 0, 10, 10), line.ExtractLine(10, 20), t)
+}
+
+func TestExtractLineNegativeIndex(t *testing.T) {
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 0, 10, 10, 20, 10))
+	checkLine(createLine(10, 5, 10, 10, 15, 10), line.ExtractLine(-15, -5), t)
+}
+
+func TestExtractLineZeroLength(t *testing.T) {
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 0))
+	checkLine(createLine(5, 0, 5, 0), line.ExtractLine(5, 5), t)
+}
+
+func TestExtractPointBeyondRange(t *testing.T) {
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 10))
+	checkPoint(NewXYCoordinate(0, 0), line.ExtractPoint(-100), t)
+	checkPoint(NewXYCoordinate(10, 10), line.ExtractPoint(100), t)
+}
+
+func TestExtractPointNegativeIndex(t *testing.T) {
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 0, 10, 10))
+	checkPoint(NewXYCoordinate(10, 8), line.ExtractPoint(-2), t)
+}
+
+func TestExtractPointEmptyLine(t *testing.T) {
+	line := NewLengthIndexedLine(nil)
+	checkPoint(NewEmptyCoordinate(), line.ExtractPoint(0), t)
+	checkPoint(NewEmptyCoordinate(), line.ExtractPointWithOffset(0, 1), t)
+	assert2.Empty(t, line.ExtractLine(0, 1))
+}
+
+func TestExtractPointWithOffset(t *testing.T) {
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 0, 10, 10))
+	checkPoint(NewXYCoordinate(5, 2), line.ExtractPointWithOffset(5, 2), t)
+	checkPoint(NewXYCoordinate(5, -2), line.ExtractPointWithOffset(5, -2), t)
+	// at an interior vertex the incoming segment is used
+	checkPoint(NewXYCoordinate(10, 2), line.ExtractPointWithOffset(10, 2), t)
+	checkPoint(NewXYCoordinate(8, 10), line.ExtractPointWithOffset(20, 2), t)
+}
+
+func TestComputeZ(t *testing.T) {
+	line := NewLengthIndexedLine([]Coordinate{NewCoordinate(0, 0, 0), NewCoordinate(10, 10, 10)})
+	index := line.IndexOf(NewXYCoordinate(5, 5))
+	pt := line.ExtractPoint(index)
+	assert2.InDelta(t, 5.0, pt.z, 1e-12)
+}
+
+func TestComputeZNaN(t *testing.T) {
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 10))
+	pt := line.ExtractPoint(5)
+	assert2.True(t, math.IsNaN(pt.z))
+}
+
+func TestProject(t *testing.T) {
+	assert := assert2.New(t)
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 0, 10, 10))
+	assert.Equal(5.0, line.Project(NewXYCoordinate(5, 3)))
+	assert.Equal(0.0, line.Project(NewXYCoordinate(-5, 3)))
+	assert.Equal(20.0, line.Project(NewXYCoordinate(20, 20)))
+	assert.Equal(15.0, line.Project(NewXYCoordinate(12, 5)))
+}
+
+func TestProjectPointWithDuplicateCoords(t *testing.T) {
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 0, 10, 0, 20, 0))
+	assert2.Equal(t, 15.0, line.Project(NewXYCoordinate(15, 1)))
+}
+
+func TestIndexOfAfterSquare(t *testing.T) {
+	assert := assert2.New(t)
+	line := NewLengthIndexedLine(createLine(0, 0, 1, 0, 1, 1, 0, 1, 0, 0))
+	pt := NewXYCoordinate(0, 0)
+	assert.Equal(0.0, line.IndexOf(pt))
+	assert.Equal(4.0, line.IndexOfAfter(pt, 0.1))
+	assert.Equal(4.0, line.IndexOfAfter(pt, 5))
+}
+
+func TestIndexOfAfterNoLaterPoint(t *testing.T) {
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 0))
+	assert2.Equal(t, 8.0, line.IndexOfAfter(NewXYCoordinate(5, 0), 8))
+}
+
+func TestClampIndex(t *testing.T) {
+	assert := assert2.New(t)
+	line := NewLengthIndexedLine(createLine(0, 0, 10, 0))
+	assert.Equal(0.0, line.ClampIndex(-20))
+	assert.Equal(7.0, line.ClampIndex(-3))
+	assert.Equal(10.0, line.ClampIndex(20))
+	assert.True(line.IsValidIndex(10))
+	assert.False(line.IsValidIndex(10.5))
+	assert.False(line.IsValidIndex(-1))
+}
+
+func checkLine(expected, actual []Coordinate, t *testing.T) {
+	assert2.True(t, equalArrays(expected, actual), "expected %v, got %v", expected, actual)
+}
+
+func checkPoint(expected, actual Coordinate, t *testing.T) {
+	assert2.True(t, expected.equals2DWithTolerance(&actual, 1e-12), "expected %v, got %v", expected, actual)
+}
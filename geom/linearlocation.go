@@ -0,0 +1,210 @@
+package geom
+
+// Represents a location along a line given as a Coordinate array.
+// The referenced line is not stored in the location;
+// it is passed to each method which needs it.
+//
+// The location is specified by:
+//   - a segment index: the index of the start vertex of the segment
+//     along which the location lies
+//   - a segment fraction: the fraction of the segment length, in [0.0, 1.0],
+//     at which the location lies
+//
+// The end point of the line is represented by the segment index of the last
+// vertex and a segment fraction of 0.0.
+type LinearLocation struct {
+	segmentIndex    int
+	segmentFraction float64
+}
+
+// Creates a location referring to the start of a line.
+func NewDefaultLinearLocation() LinearLocation {
+	return LinearLocation{}
+}
+
+// Creates a location referring to the given segment and fraction.
+// The location is normalized, so that a fraction of 1.0 refers
+// to the start of the following segment.
+func NewLinearLocation(segmentIndex int, segmentFraction float64) LinearLocation {
+	result := LinearLocation{
+		segmentIndex:    segmentIndex,
+		segmentFraction: segmentFraction,
+	}
+	result.normalize()
+	return result
+}
+
+// Gets a location which refers to the end of a line.
+func getEndLocation(pts []Coordinate) LinearLocation {
+	if len(pts) == 0 {
+		return NewDefaultLinearLocation()
+	}
+	return LinearLocation{segmentIndex: len(pts) - 1}
+}
+
+// Computes the Coordinate of a point a given fraction
+// along the line segment (p0, p1).
+// If the fraction is greater than 1.0 the last
+// point of the segment is returned.
+// If the fraction is less than or equal to 0.0 the first point
+// of the segment is returned.
+// The Z ordinate is interpolated from the Z ordinates of the given points,
+// if they are specified.
+func pointAlongSegmentByFraction(p0, p1 Coordinate, frac float64) Coordinate {
+	if frac <= 0.0 {
+		return p0
+	}
+	if frac >= 1.0 {
+		return p1
+	}
+	result := NewCoordinate(
+		(p1.x-p0.x)*frac+p0.x,
+		(p1.y-p0.y)*frac+p0.y,
+		(p1.z-p0.z)*frac+p0.z,
+	)
+	result.dimensions = MaxInt(p0.dimensions, p1.dimensions)
+	return result
+}
+
+// Ensures the individual values are locally valid.
+// Does not ensure that the indexes are valid for a particular line.
+func (l *LinearLocation) normalize() {
+	if l.segmentFraction < 0.0 {
+		l.segmentFraction = 0.0
+	}
+	if l.segmentFraction > 1.0 {
+		l.segmentFraction = 1.0
+	}
+	if l.segmentIndex < 0 {
+		l.segmentIndex = 0
+		l.segmentFraction = 0.0
+	}
+	if l.segmentFraction == 1.0 {
+		l.segmentFraction = 0.0
+		l.segmentIndex++
+	}
+}
+
+// Ensures the indexes are valid for a given line.
+// Locations beyond the end of the line are clamped to the end point.
+func (l *LinearLocation) clamp(pts []Coordinate) {
+	if l.segmentIndex >= len(pts)-1 {
+		*l = getEndLocation(pts)
+	}
+}
+
+// Snaps the value of this location to
+// the nearest vertex on the given line,
+// if the vertex is closer than minDistance.
+func (l *LinearLocation) snapToVertex(pts []Coordinate, minDistance float64) {
+	if l.segmentFraction <= 0.0 || l.segmentFraction >= 1.0 {
+		return
+	}
+	segLen := l.getSegmentLength(pts)
+	lenToStart := l.segmentFraction * segLen
+	lenToEnd := segLen - lenToStart
+	if lenToStart <= lenToEnd && lenToStart < minDistance {
+		l.segmentFraction = 0.0
+	} else if lenToEnd <= lenToStart && lenToEnd < minDistance {
+		l.segmentFraction = 1.0
+	}
+	l.normalize()
+}
+
+// Gets the length of the segment in the given line
+// indicated by this location.
+func (l *LinearLocation) getSegmentLength(pts []Coordinate) float64 {
+	if len(pts) < 2 {
+		return 0.0
+	}
+	// ensure segment index is valid
+	segIndex := l.segmentIndex
+	if segIndex >= len(pts)-1 {
+		segIndex = len(pts) - 2
+	}
+	return pts[segIndex].distance(&pts[segIndex+1])
+}
+
+// Gets the segment of the line which this location lies on.
+// The location at the end of the line refers to the last segment.
+// An empty line gives a segment between empty Coordinate(s).
+func (l *LinearLocation) getSegment(pts []Coordinate) LineSegment {
+	if len(pts) == 0 {
+		return NewLineSegment(NewEmptyCoordinate(), NewEmptyCoordinate())
+	}
+	if len(pts) == 1 {
+		return NewLineSegment(pts[0], pts[0])
+	}
+	if l.segmentIndex >= len(pts)-1 {
+		return NewLineSegment(pts[len(pts)-2], pts[len(pts)-1])
+	}
+	return NewLineSegment(pts[l.segmentIndex], pts[l.segmentIndex+1])
+}
+
+// Converts a location at a vertex into the equivalent location
+// at the end of the previous segment, if there is one.
+// This is the lowest segment index the location can be expressed with.
+func (l *LinearLocation) toLowest(pts []Coordinate) LinearLocation {
+	if l.segmentFraction > 0.0 || l.segmentIndex <= 0 {
+		return *l
+	}
+	segIndex := l.segmentIndex
+	if segIndex > len(pts)-1 {
+		segIndex = len(pts) - 1
+	}
+	return LinearLocation{segmentIndex: segIndex - 1, segmentFraction: 1.0}
+}
+
+// Gets the Coordinate along the given line
+// which is referenced by this location.
+// An empty line gives an empty Coordinate.
+func (l *LinearLocation) getCoordinate(pts []Coordinate) Coordinate {
+	if len(pts) == 0 {
+		return NewEmptyCoordinate()
+	}
+	if l.segmentIndex >= len(pts)-1 {
+		return pts[len(pts)-1]
+	}
+	return pointAlongSegmentByFraction(pts[l.segmentIndex], pts[l.segmentIndex+1], l.segmentFraction)
+}
+
+// Tests whether this location refers to a valid
+// location on the given line.
+func (l *LinearLocation) isValid(pts []Coordinate) bool {
+	if l.segmentIndex < 0 || l.segmentIndex >= len(pts) {
+		return false
+	}
+	if l.segmentIndex == len(pts)-1 && l.segmentFraction > 0.0 {
+		return false
+	}
+	return l.segmentFraction >= 0.0 && l.segmentFraction <= 1.0
+}
+
+// Tests whether this location refers to a vertex.
+func (l *LinearLocation) isVertex() bool {
+	return l.segmentFraction <= 0.0 || l.segmentFraction >= 1.0
+}
+
+// Tests whether this location is an endpoint of the given line.
+func (l *LinearLocation) isEndpoint(pts []Coordinate) bool {
+	nseg := len(pts) - 1
+	return l.segmentIndex >= nseg ||
+		(l.segmentIndex == nseg-1 && l.segmentFraction >= 1.0)
+}
+
+// Compares this location with another for order.
+func (l *LinearLocation) compareTo(other LinearLocation) int {
+	if l.segmentIndex < other.segmentIndex {
+		return -1
+	}
+	if l.segmentIndex > other.segmentIndex {
+		return 1
+	}
+	if l.segmentFraction < other.segmentFraction {
+		return -1
+	}
+	if l.segmentFraction > other.segmentFraction {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,114 @@
+package geom
+
+// A node of a lineMergeGraph, located at the endpoint of one or more lines.
+type lineNode struct {
+	pt    Coordinate
+	edges []*lineDirectedEdge
+}
+
+// Returns the number of directed edges leaving this node.
+func (n *lineNode) degree() int {
+	return len(n.edges)
+}
+
+// An edge of a lineMergeGraph, holding the coordinates of a single input line.
+type lineEdge struct {
+	pts     []Coordinate
+	visited bool
+}
+
+// One direction of traversal of a lineEdge.
+// If edgeDirection is true the edge is traversed from its first coordinate
+// to its last one.
+type lineDirectedEdge struct {
+	edge          *lineEdge
+	from          *lineNode
+	to            *lineNode
+	sym           *lineDirectedEdge
+	edgeDirection bool
+}
+
+// Returns the coordinates of the directed edge in the direction of traversal.
+func (de *lineDirectedEdge) coordinates() []Coordinate {
+	pts := copyDeepCoordinateArray(de.edge.pts)
+	if !de.edgeDirection {
+		reverse(pts)
+	}
+	return pts
+}
+
+// Returns the next directed edge when passing through the end node
+// of this directed edge, if the end node has degree 2.
+// Returns nil otherwise.
+func (de *lineDirectedEdge) next() *lineDirectedEdge {
+	if de.to.degree() != 2 {
+		return nil
+	}
+	if de.to.edges[0] == de.sym {
+		return de.to.edges[1]
+	}
+	return de.to.edges[0]
+}
+
+// A planar graph of edges that is analyzed to sew the edges together.
+// The nodes of the graph are the line endpoints; the edges are the lines.
+type lineMergeGraph struct {
+	nodes map[CoordinateKey]*lineNode
+	// nodes in the order they were created, to keep results deterministic
+	nodeList []*lineNode
+	edges    []*lineEdge
+}
+
+func newLineMergeGraph() lineMergeGraph {
+	return lineMergeGraph{
+		nodes: make(map[CoordinateKey]*lineNode),
+	}
+}
+
+// Adds an edge for the line to the graph.
+// Repeated points are removed first; lines which collapse to a single point
+// are ignored.
+func (g *lineMergeGraph) addEdge(pts []Coordinate) {
+	pts = copyDeepCoordinateArray(removeRepeatedPoints(pts))
+	if len(pts) < 2 {
+		return
+	}
+	start := g.getNode(pts[0])
+	end := g.getNode(pts[len(pts)-1])
+	edge := &lineEdge{pts: pts}
+	forward := &lineDirectedEdge{edge: edge, from: start, to: end, edgeDirection: true}
+	backward := &lineDirectedEdge{edge: edge, from: end, to: start, edgeDirection: false}
+	forward.sym = backward
+	backward.sym = forward
+	start.edges = append(start.edges, forward)
+	end.edges = append(end.edges, backward)
+	g.edges = append(g.edges, edge)
+}
+
+// Gets the node at a line endpoint, creating it if needed.
+// Nodes are looked up by their CoordinateKey, so endpoints are matched
+// exactly as by Coordinate.equals2D.
+func (g *lineMergeGraph) getNode(pt Coordinate) *lineNode {
+	key := CoordinateKey{pt.x, pt.y}
+	node, ok := g.nodes[key]
+	if !ok {
+		node = &lineNode{pt: pt}
+		g.nodes[key] = node
+		g.nodeList = append(g.nodeList, node)
+	}
+	return node
+}
+
+// Joins the coordinates of a sequence of directed edges into a single line.
+// The shared endpoints of consecutive edges are only included once.
+func joinDirectedEdges(des []*lineDirectedEdge) []Coordinate {
+	var result []Coordinate
+	for i, de := range des {
+		pts := de.coordinates()
+		if i > 0 {
+			pts = pts[1:]
+		}
+		result = append(result, pts...)
+	}
+	return result
+}
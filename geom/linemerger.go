@@ -0,0 +1,100 @@
+package geom
+
+// Merges a collection of linear components to form maximal-length linestrings.
+//
+// Merging stops at nodes of degree 1 or degree 3 or more.
+// In other words, all nodes of degree 2 are merged together.
+// The exception is in the case of an isolated loop, which only has degree-2 nodes.
+// In this case one of the nodes is chosen as a starting point.
+//
+// The direction of each merged linestring will be that of the majority
+// of the lines which were merged.
+//
+// Lines are given as Coordinate arrays; end points are matched
+// using Coordinate.equals2D. Lines which collapse to a point are ignored.
+type LineMerger struct {
+	graph  lineMergeGraph
+	merged [][]Coordinate
+}
+
+// Creates a new LineMerger with no lines added.
+func NewLineMerger() LineMerger {
+	return LineMerger{
+		graph: newLineMergeGraph(),
+	}
+}
+
+// Adds a line to be merged.
+func (m *LineMerger) Add(pts []Coordinate) {
+	m.merged = nil
+	m.graph.addEdge(pts)
+}
+
+// Adds a collection of lines to be merged.
+func (m *LineMerger) AddAll(lines [][]Coordinate) {
+	for _, pts := range lines {
+		m.Add(pts)
+	}
+}
+
+// Gets the merged lines, as Coordinate arrays.
+func (m *LineMerger) GetMergedLineStrings() [][]Coordinate {
+	m.merge()
+	return m.merged
+}
+
+func (m *LineMerger) merge() {
+	if m.merged != nil {
+		return
+	}
+	for _, e := range m.graph.edges {
+		e.visited = false
+	}
+	m.merged = make([][]Coordinate, 0)
+	m.buildEdgeStringsForObviousStartNodes()
+	m.buildEdgeStringsForIsolatedLoops()
+}
+
+func (m *LineMerger) buildEdgeStringsForObviousStartNodes() {
+	for _, node := range m.graph.nodeList {
+		if node.degree() != 2 {
+			m.buildEdgeStringsStartingAt(node)
+		}
+	}
+}
+
+func (m *LineMerger) buildEdgeStringsForIsolatedLoops() {
+	for _, node := range m.graph.nodeList {
+		if node.degree() == 2 {
+			m.buildEdgeStringsStartingAt(node)
+		}
+	}
+}
+
+func (m *LineMerger) buildEdgeStringsStartingAt(node *lineNode) {
+	for _, de := range node.edges {
+		if de.edge.visited {
+			continue
+		}
+		m.merged = append(m.merged, m.buildEdgeStringStartingWith(de))
+	}
+}
+
+func (m *LineMerger) buildEdgeStringStartingWith(start *lineDirectedEdge) []Coordinate {
+	var des []*lineDirectedEdge
+	forward := 0
+	current := start
+	for current != nil && !current.edge.visited {
+		des = append(des, current)
+		current.edge.visited = true
+		if current.edgeDirection {
+			forward++
+		}
+		current = current.next()
+	}
+	pts := joinDirectedEdges(des)
+	if forward < len(des)-forward {
+		reverse(pts)
+	}
+	return pts
+}
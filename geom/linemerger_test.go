@@ -0,0 +1,88 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLineMergerSimple(t *testing.T) {
+	checkLineMerge([][]Coordinate{
+		createLine(120, 120, 180, 140),
+		createLine(200, 180, 180, 140),
+		createLine(200, 180, 240, 180),
+	}, [][]Coordinate{
+		createLine(120, 120, 180, 140, 200, 180, 240, 180),
+	}, t)
+}
+
+func TestLineMergerDirectionOfMajority(t *testing.T) {
+	checkLineMerge([][]Coordinate{
+		createLine(180, 140, 120, 120),
+		createLine(200, 180, 180, 140),
+		createLine(200, 180, 240, 180),
+	}, [][]Coordinate{
+		createLine(240, 180, 200, 180, 180, 140, 120, 120),
+	}, t)
+}
+
+func TestLineMergerStopsAtDegree3Nodes(t *testing.T) {
+	checkLineMerge([][]Coordinate{
+		createLine(0, 0, 10, 10),
+		createLine(10, 10, 20, 20),
+		createLine(10, 10, 20, 0),
+		createLine(20, 0, 30, 0),
+	}, [][]Coordinate{
+		createLine(0, 0, 10, 10),
+		createLine(10, 10, 20, 20),
+		createLine(10, 10, 20, 0, 30, 0),
+	}, t)
+}
+
+func TestLineMergerIsolatedLoop(t *testing.T) {
+	checkLineMerge([][]Coordinate{
+		createLine(0, 0, 10, 0),
+		createLine(10, 0, 10, 10),
+		createLine(0, 0, 10, 10),
+	}, [][]Coordinate{
+		createLine(0, 0, 10, 0, 10, 10, 0, 0),
+	}, t)
+}
+
+func TestLineMergerIgnoresCollapsedLines(t *testing.T) {
+	checkLineMerge([][]Coordinate{
+		createLine(0, 0, 0, 0),
+		createLine(0, 0, 10, 0, 10, 0),
+		createLine(10, 0, 20, 0),
+	}, [][]Coordinate{
+		createLine(0, 0, 10, 0, 20, 0),
+	}, t)
+}
+
+func TestLineMergerEmpty(t *testing.T) {
+	merger := NewLineMerger()
+	assert2.Empty(t, merger.GetMergedLineStrings())
+}
+
+func checkLineMerge(input, expected [][]Coordinate, t *testing.T) {
+	merger := NewLineMerger()
+	merger.AddAll(input)
+	checkLinesEqual(expected, merger.GetMergedLineStrings(), t)
+}
+
+func checkLinesEqual(expected, actual [][]Coordinate, t *testing.T) {
+	assert := assert2.New(t)
+	if !assert.Equal(len(expected), len(actual), "unexpected number of lines: %v", actual) {
+		return
+	}
+	for i := range expected {
+		assert.True(equalArrays(expected[i], actual[i]), "expected %v, got %v", expected[i], actual[i])
+	}
+}
+
+func createLine(ords ...float64) []Coordinate {
+	pts := make([]Coordinate, 0, len(ords)/2)
+	for i := 0; i+1 < len(ords); i += 2 {
+		pts = append(pts, NewXYCoordinate(ords[i], ords[i+1]))
+	}
+	return pts
+}
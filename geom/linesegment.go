@@ -0,0 +1,174 @@
+package geom
+
+import "math"
+
+// Represents a line segment defined by two Coordinate(s).
+// Provides methods to compute various geometric properties
+// and relationships of line segments.
+type LineSegment struct {
+	p0 Coordinate
+	p1 Coordinate
+}
+
+// Creates a LineSegment between two Coordinate(s).
+func NewLineSegment(p0, p1 Coordinate) LineSegment {
+	return LineSegment{
+		p0: p0,
+		p1: p1,
+	}
+}
+
+// Computes the length of the line segment.
+func (s *LineSegment) getLength() float64 {
+	return s.p0.distance(&s.p1)
+}
+
+// Computes the Projection Factor for the projection of the point p
+// onto this LineSegment. The Projection Factor is the constant r
+// by which the vector for this segment must be multiplied to
+// equal the vector for the projection of p on the line
+// defined by this segment.
+//
+// The projection factor will lie in the range (-inf, +inf),
+// or be NaN if the line segment has zero length.
+func (s *LineSegment) projectionFactor(p Coordinate) float64 {
+	if p.equals(&s.p0) {
+		return 0.0
+	}
+	if p.equals(&s.p1) {
+		return 1.0
+	}
+	dx := s.p1.x - s.p0.x
+	dy := s.p1.y - s.p0.y
+	len2 := dx*dx + dy*dy
+	// handle zero-length segments
+	if len2 <= 0.0 {
+		return math.NaN()
+	}
+	return ((p.x-s.p0.x)*dx + (p.y-s.p0.y)*dy) / len2
+}
+
+// Computes the fraction of distance (in [0.0, 1.0])
+// that the projection of a point occurs along this line segment.
+// If the point is beyond either ends of the line segment,
+// the closest fractional value (0.0 or 1.0) is returned.
+//
+// Essentially, this is the projectionFactor clamped to
+// the range [0.0, 1.0].
+// If the segment has zero length, 1.0 is returned.
+func (s *LineSegment) segmentFraction(p Coordinate) float64 {
+	segFrac := s.projectionFactor(p)
+	if math.IsNaN(segFrac) {
+		return 1.0
+	}
+	if segFrac < 0.0 {
+		return 0.0
+	}
+	if segFrac > 1.0 {
+		return 1.0
+	}
+	return segFrac
+}
+
+// Compute the projection of a point onto the line determined
+// by this line segment.
+//
+// Note that the projected point
+// may lie outside the line segment.  If this is the case,
+// the projection factor will lie outside the range [0.0, 1.0].
+func (s *LineSegment) project(p Coordinate) Coordinate {
+	if p.equals(&s.p0) || p.equals(&s.p1) {
+		return p.clone()
+	}
+	r := s.projectionFactor(p)
+	if math.IsNaN(r) {
+		return s.p0.clone()
+	}
+	return NewXYCoordinate(s.p0.x+r*(s.p1.x-s.p0.x), s.p0.y+r*(s.p1.y-s.p0.y))
+}
+
+// Computes the closest point on this line segment to another point.
+func (s *LineSegment) closestPoint(p Coordinate) Coordinate {
+	factor := s.projectionFactor(p)
+	if factor > 0 && factor < 1 {
+		return s.project(p)
+	}
+	dist0 := s.p0.distance(&p)
+	dist1 := s.p1.distance(&p)
+	if dist0 < dist1 {
+		return s.p0.clone()
+	}
+	return s.p1.clone()
+}
+
+// Computes the distance between this line segment and a given point.
+func (s *LineSegment) distance(p Coordinate) float64 {
+	closest := s.closestPoint(p)
+	return closest.distance(&p)
+}
+
+// Computes the Coordinate that lies a given
+// fraction along the line defined by this segment.
+// A fraction of 0.0 returns the start point of the segment;
+// a fraction of 1.0 returns the end point of the segment.
+// If the fraction is < 0.0 or > 1.0 the point returned
+// will lie before the start or beyond the end of the segment.
+func (s *LineSegment) pointAlong(segmentLengthFraction float64) Coordinate {
+	return NewXYCoordinate(
+		s.p0.x+segmentLengthFraction*(s.p1.x-s.p0.x),
+		s.p0.y+segmentLengthFraction*(s.p1.y-s.p0.y),
+	)
+}
+
+// Computes the Coordinate that lies a given
+// fraction along the line defined by this line segment and offset from
+// the segment by a given distance.
+// A fraction of 0.0 offsets from the start point of the segment;
+// a fraction of 1.0 offsets from the end point of the segment.
+// The computed point is offset to the left of the line if the offset distance is
+// positive, to the right if negative.
+func (s *LineSegment) pointAlongOffset(segmentLengthFraction, offsetDistance float64) Coordinate {
+	// the point on the segment line
+	segx := s.p0.x + segmentLengthFraction*(s.p1.x-s.p0.x)
+	segy := s.p0.y + segmentLengthFraction*(s.p1.y-s.p0.y)
+
+	dx := s.p1.x - s.p0.x
+	dy := s.p1.y - s.p0.y
+	length := math.Sqrt(dx*dx + dy*dy)
+	ux := 0.0
+	uy := 0.0
+	if offsetDistance != 0.0 && length > 0.0 {
+		// u is the vector that is the length of the offset, in the direction of the segment
+		ux = offsetDistance * dx / length
+		uy = offsetDistance * dy / length
+	}
+	// the offset point is the seg point plus the offset vector rotated 90 degrees CCW
+	return NewXYCoordinate(segx-uy, segy+ux)
+}
+
+// Computes the midpoint of the segment
+func (s *LineSegment) midPoint() Coordinate {
+	return NewXYCoordinate((s.p0.x+s.p1.x)/2, (s.p0.y+s.p1.y)/2)
+}
+
+// Computes the intersection point of this segment with another,
+// if they intersect in a single point.
+// Collinear segments are reported as not intersecting.
+func (s *LineSegment) intersection(other LineSegment) (Coordinate, bool) {
+	dx := s.p1.x - s.p0.x
+	dy := s.p1.y - s.p0.y
+	odx := other.p1.x - other.p0.x
+	ody := other.p1.y - other.p0.y
+	denom := dx*ody - dy*odx
+	if denom == 0 {
+		return Coordinate{}, false
+	}
+	qx := other.p0.x - s.p0.x
+	qy := other.p0.y - s.p0.y
+	t := (qx*ody - qy*odx) / denom
+	u := (qx*dy - qy*dx) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Coordinate{}, false
+	}
+	return NewXYCoordinate(s.p0.x+t*dx, s.p0.y+t*dy), true
+}
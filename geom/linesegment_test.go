@@ -0,0 +1,40 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestProjectionFactor(t *testing.T) {
+	assert := assert2.New(t)
+	seg := NewLineSegment(NewXYCoordinate(10, 0), NewXYCoordinate(20, 0))
+	assert.Equal(0.0, seg.projectionFactor(NewXYCoordinate(10, 5)))
+	assert.Equal(0.5, seg.projectionFactor(NewXYCoordinate(15, 5)))
+	assert.Equal(-1.0, seg.projectionFactor(NewXYCoordinate(0, 5)))
+	assert.Equal(2.0, seg.projectionFactor(NewXYCoordinate(30, -5)))
+	zero := NewLineSegment(NewXYCoordinate(10, 0), NewXYCoordinate(10, 0))
+	assert.True(math.IsNaN(zero.projectionFactor(NewXYCoordinate(15, 5))))
+	assert.Equal(1.0, zero.segmentFraction(NewXYCoordinate(15, 5)))
+}
+
+func TestSegmentDistance(t *testing.T) {
+	assert := assert2.New(t)
+	seg := NewLineSegment(NewXYCoordinate(0, 0), NewXYCoordinate(10, 0))
+	assert.Equal(5.0, seg.distance(NewXYCoordinate(5, 5)))
+	assert.Equal(5.0, seg.distance(NewXYCoordinate(-3, 4)))
+	assert.Equal(0.0, seg.distance(NewXYCoordinate(10, 0)))
+}
+
+func TestPointAlongOffset(t *testing.T) {
+	seg := NewLineSegment(NewXYCoordinate(0, 0), NewXYCoordinate(10, 0))
+	checkPointAlongOffset(seg, 0.5, 2, 5, 2, t)
+	checkPointAlongOffset(seg, 0.5, -2, 5, -2, t)
+	checkPointAlongOffset(seg, 1, 0, 10, 0, t)
+}
+
+func checkPointAlongOffset(seg LineSegment, fraction, offset, x, y float64, t *testing.T) {
+	p := seg.pointAlongOffset(fraction, offset)
+	expected := NewXYCoordinate(x, y)
+	assert2.True(t, p.equals2DWithTolerance(&expected, 1e-12), "expected %v, got %v", expected, p)
+}
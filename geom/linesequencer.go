@@ -0,0 +1,267 @@
+package geom
+
+// Builds a sequence from a set of lines,
+// such that the end point of each line
+// equals the start point of the next line in the sequence.
+// Lines may be reversed in order to achieve this.
+//
+// A set of lines can be sequenced if each connected component of the set
+// contains at most two nodes of odd degree (that is, it has an Euler path).
+// Lines are oriented so that, where possible, a sequence starts
+// at a node of degree 1 and the lines keep their original direction.
+//
+// Lines are given as Coordinate arrays; end points are matched
+// using Coordinate.equals2D. Lines which collapse to a point are ignored.
+type LineSequencer struct {
+	graph        lineMergeGraph
+	sequenced    [][]Coordinate
+	sequenceable bool
+	run          bool
+}
+
+// Creates a new LineSequencer with no lines added.
+func NewLineSequencer() LineSequencer {
+	return LineSequencer{
+		graph: newLineMergeGraph(),
+	}
+}
+
+// Tests whether an arrangement of lines is sequenced,
+// that is whether every connected component is traversed
+// end-to-start, with no node of a finished component re-used later on.
+func IsSequenced(lines [][]Coordinate) bool {
+	prevSubgraphNodes := make(map[CoordinateKey]bool)
+	currNodes := make(map[CoordinateKey]bool)
+	var lastNode *Coordinate
+	for _, pts := range lines {
+		if len(pts) == 0 {
+			continue
+		}
+		startNode := pts[0]
+		endNode := pts[len(pts)-1]
+		if lastNode != nil && !startNode.equals2D(lastNode) {
+			// starting a new connected sequence
+			for k := range currNodes {
+				prevSubgraphNodes[k] = true
+			}
+			currNodes = make(map[CoordinateKey]bool)
+		}
+		startKey := CoordinateKey{startNode.x, startNode.y}
+		endKey := CoordinateKey{endNode.x, endNode.y}
+		if prevSubgraphNodes[startKey] || prevSubgraphNodes[endKey] {
+			return false
+		}
+		currNodes[startKey] = true
+		currNodes[endKey] = true
+		lastNode = &endNode
+	}
+	return true
+}
+
+// Adds a line to be sequenced.
+func (s *LineSequencer) Add(pts []Coordinate) {
+	s.run = false
+	s.graph.addEdge(pts)
+}
+
+// Adds a collection of lines to be sequenced.
+func (s *LineSequencer) AddAll(lines [][]Coordinate) {
+	for _, pts := range lines {
+		s.Add(pts)
+	}
+}
+
+// Tests whether the added lines can be sequenced.
+func (s *LineSequencer) IsSequenceable() bool {
+	s.computeSequence()
+	return s.sequenceable
+}
+
+// Returns the sequenced lines, or nil if the lines
+// could not be sequenced.
+func (s *LineSequencer) GetSequencedLineStrings() [][]Coordinate {
+	s.computeSequence()
+	return s.sequenced
+}
+
+func (s *LineSequencer) computeSequence() {
+	if s.run {
+		return
+	}
+	s.run = true
+	s.sequenced = nil
+	s.sequenceable = false
+	for _, e := range s.graph.edges {
+		e.visited = false
+	}
+	var result [][]Coordinate
+	for _, subgraph := range s.graph.connectedSubgraphs() {
+		if !hasSequence(subgraph) {
+			return
+		}
+		for _, de := range orientSequence(findSequence(subgraph)) {
+			result = append(result, de.coordinates())
+		}
+	}
+	s.sequenced = result
+	if s.sequenced == nil {
+		s.sequenced = make([][]Coordinate, 0)
+	}
+	s.sequenceable = true
+}
+
+// Splits the graph nodes into connected components.
+func (g *lineMergeGraph) connectedSubgraphs() [][]*lineNode {
+	var subgraphs [][]*lineNode
+	seen := make(map[*lineNode]bool)
+	for _, node := range g.nodeList {
+		if seen[node] {
+			continue
+		}
+		seen[node] = true
+		subgraph := []*lineNode{node}
+		for i := 0; i < len(subgraph); i++ {
+			for _, de := range subgraph[i].edges {
+				if !seen[de.to] {
+					seen[de.to] = true
+					subgraph = append(subgraph, de.to)
+				}
+			}
+		}
+		subgraphs = append(subgraphs, subgraph)
+	}
+	return subgraphs
+}
+
+// Tests whether a connected subgraph has an Euler path,
+// that is whether it has no more than 2 nodes of odd degree.
+func hasSequence(subgraph []*lineNode) bool {
+	oddDegreeCount := 0
+	for _, node := range subgraph {
+		if node.degree()%2 == 1 {
+			oddDegreeCount++
+		}
+	}
+	return oddDegreeCount <= 2
+}
+
+// Finds the node to start the sequence from:
+// the odd-degree node of lowest degree if there is one,
+// the node of lowest degree otherwise.
+func findStartNode(subgraph []*lineNode) *lineNode {
+	var start *lineNode
+	for _, node := range subgraph {
+		if start == nil {
+			start = node
+			continue
+		}
+		nodeOdd := node.degree()%2 == 1
+		startOdd := start.degree()%2 == 1
+		if nodeOdd != startOdd {
+			if nodeOdd {
+				start = node
+			}
+			continue
+		}
+		if node.degree() < start.degree() {
+			start = node
+		}
+	}
+	return start
+}
+
+// Finds an unvisited directed edge leaving the node,
+// preferring those which follow the direction of their line.
+func findUnvisitedBestOrientedDE(node *lineNode) *lineDirectedEdge {
+	var wellOriented, unvisited *lineDirectedEdge
+	for _, de := range node.edges {
+		if de.edge.visited {
+			continue
+		}
+		if unvisited == nil {
+			unvisited = de
+		}
+		if de.edgeDirection && wellOriented == nil {
+			wellOriented = de
+		}
+	}
+	if wellOriented != nil {
+		return wellOriented
+	}
+	return unvisited
+}
+
+// Computes an Euler path through a connected subgraph using Hierholzer's algorithm.
+func findSequence(subgraph []*lineNode) []*lineDirectedEdge {
+	var stack, path []*lineDirectedEdge
+	node := findStartNode(subgraph)
+	for {
+		de := findUnvisitedBestOrientedDE(node)
+		if de != nil {
+			de.edge.visited = true
+			stack = append(stack, de)
+			node = de.to
+			continue
+		}
+		if len(stack) == 0 {
+			break
+		}
+		de = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		path = append(path, de)
+		node = de.from
+	}
+	// edges were collected from the end of the path
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Computes a version of the sequence which is optimally
+// oriented relative to the underlying geometry.
+//
+// Heuristics used are:
+//   - If the path has a degree-1 node which is the start
+//     node of a line, use that node as the start of the sequence
+//   - If the path has a degree-1 node which is the end
+//     node of a line, use that node as the end of the sequence
+//   - If the sequence has no degree-1 nodes, use any node as the start
+func orientSequence(seq []*lineDirectedEdge) []*lineDirectedEdge {
+	if len(seq) == 0 {
+		return seq
+	}
+	startEdge := seq[0]
+	endEdge := seq[len(seq)-1]
+	flipSeq := false
+	if startEdge.from.degree() == 1 || endEdge.to.degree() == 1 {
+		hasObviousStartNode := false
+		// test end edge before start edge, to make result stable
+		// (ie. if both are good starts, pick the actual start)
+		if endEdge.to.degree() == 1 && !endEdge.edgeDirection {
+			hasObviousStartNode = true
+			flipSeq = true
+		}
+		if startEdge.from.degree() == 1 && startEdge.edgeDirection {
+			hasObviousStartNode = true
+			flipSeq = false
+		}
+		// since there is no obvious start node, use any node of degree 1
+		if !hasObviousStartNode && startEdge.from.degree() == 1 {
+			flipSeq = true
+		}
+	}
+	if flipSeq {
+		return reverseSequence(seq)
+	}
+	return seq
+}
+
+// Reverses the sequence, traversing each edge in the opposite direction.
+func reverseSequence(seq []*lineDirectedEdge) []*lineDirectedEdge {
+	result := make([]*lineDirectedEdge, len(seq))
+	for i, de := range seq {
+		result[len(seq)-1-i] = de.sym
+	}
+	return result
+}
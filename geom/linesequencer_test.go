@@ -0,0 +1,102 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLineSequencerSimple(t *testing.T) {
+	checkLineSequence([][]Coordinate{
+		createLine(0, 0, 0, 10),
+		createLine(0, 20, 0, 30),
+		createLine(0, 10, 0, 20),
+	}, [][]Coordinate{
+		createLine(0, 0, 0, 10),
+		createLine(0, 10, 0, 20),
+		createLine(0, 20, 0, 30),
+	}, t)
+}
+
+func TestLineSequencerReversesLines(t *testing.T) {
+	checkLineSequence([][]Coordinate{
+		createLine(0, 0, 0, 10),
+		createLine(0, 20, 0, 10),
+		createLine(0, 20, 0, 30),
+	}, [][]Coordinate{
+		createLine(0, 0, 0, 10),
+		createLine(0, 10, 0, 20),
+		createLine(0, 20, 0, 30),
+	}, t)
+}
+
+func TestLineSequencerDisjointComponents(t *testing.T) {
+	checkLineSequence([][]Coordinate{
+		createLine(0, 0, 0, 10),
+		createLine(100, 0, 100, 10),
+	}, [][]Coordinate{
+		createLine(0, 0, 0, 10),
+		createLine(100, 0, 100, 10),
+	}, t)
+}
+
+func TestLineSequencerRing(t *testing.T) {
+	checkLineSequence([][]Coordinate{
+		createLine(0, 0, 0, 10),
+		createLine(0, 10, 10, 10),
+		createLine(10, 10, 0, 0),
+	}, [][]Coordinate{
+		createLine(0, 0, 0, 10),
+		createLine(0, 10, 10, 10),
+		createLine(10, 10, 0, 0),
+	}, t)
+}
+
+func TestLineSequencerEulerPathThroughDegree3Nodes(t *testing.T) {
+	sequencer := NewLineSequencer()
+	sequencer.AddAll([][]Coordinate{
+		createLine(0, 0, 10, 0),
+		createLine(10, 0, 10, 10),
+		createLine(10, 10, 0, 10),
+		createLine(0, 10, 0, 0),
+		createLine(0, 0, 10, 10),
+	})
+	assert2.True(t, sequencer.IsSequenceable())
+	result := sequencer.GetSequencedLineStrings()
+	assert2.Equal(t, 5, len(result))
+	assert2.True(t, IsSequenced(result))
+}
+
+func TestLineSequencerNotSequenceable(t *testing.T) {
+	sequencer := NewLineSequencer()
+	sequencer.AddAll([][]Coordinate{
+		createLine(0, 0, 10, 10),
+		createLine(10, 10, 20, 20),
+		createLine(10, 10, 20, 0),
+		createLine(10, 10, 0, 20),
+	})
+	assert2.False(t, sequencer.IsSequenceable())
+	assert2.Nil(t, sequencer.GetSequencedLineStrings())
+}
+
+func TestIsSequenced(t *testing.T) {
+	assert := assert2.New(t)
+	assert.True(IsSequenced([][]Coordinate{
+		createLine(0, 0, 0, 10),
+		createLine(0, 10, 0, 20),
+		createLine(100, 0, 100, 10),
+	}))
+	assert.False(IsSequenced([][]Coordinate{
+		createLine(0, 0, 0, 10),
+		createLine(100, 0, 100, 10),
+		createLine(0, 10, 0, 20),
+	}))
+}
+
+func checkLineSequence(input, expected [][]Coordinate, t *testing.T) {
+	sequencer := NewLineSequencer()
+	sequencer.AddAll(input)
+	assert2.True(t, sequencer.IsSequenceable())
+	result := sequencer.GetSequencedLineStrings()
+	checkLinesEqual(expected, result, t)
+	assert2.True(t, IsSequenced(result))
+}
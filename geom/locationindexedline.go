@@ -0,0 +1,157 @@
+package geom
+
+import "math"
+
+// Supports linear referencing along a line
+// using LinearLocation(s) as the index.
+type LocationIndexedLine struct {
+	pts []Coordinate
+}
+
+// Constructs an object which allows linear referencing along
+// a given line, given as a Coordinate array.
+func NewLocationIndexedLine(pts []Coordinate) LocationIndexedLine {
+	return LocationIndexedLine{
+		pts: pts,
+	}
+}
+
+// Computes the Coordinate for the point on the line at the given index.
+// If the index is out of range the first or last point on the
+// line will be returned.
+// The Z ordinate of the computed point will be interpolated from
+// the Z ordinates of the line segment containing it, if they exist.
+func (l *LocationIndexedLine) ExtractPoint(index LinearLocation) Coordinate {
+	index.clamp(l.pts)
+	return index.getCoordinate(l.pts)
+}
+
+// Computes the Coordinate for the point on the line at the given index,
+// offset by the given distance.
+// A positive offset is to the left of the line, a negative one to the right.
+func (l *LocationIndexedLine) ExtractPointWithOffset(index LinearLocation, offsetDistance float64) Coordinate {
+	index.clamp(l.pts)
+	lowest := index.toLowest(l.pts)
+	seg := lowest.getSegment(l.pts)
+	return seg.pointAlongOffset(lowest.segmentFraction, offsetDistance)
+}
+
+// Computes the line between two indices.
+// If the start index is after the end index,
+// the computed line will be reversed.
+func (l *LocationIndexedLine) ExtractLine(startIndex, endIndex LinearLocation) []Coordinate {
+	return extractLineByLocation(l.pts, startIndex, endIndex)
+}
+
+// Computes the index for a given point on the line.
+//
+// The supplied point does not necessarily have to lie precisely
+// on the line, but if it is far from the line the accuracy and
+// performance of this function is not guaranteed.
+// Use Project to compute a guaranteed result for points
+// which may be far from the line.
+func (l *LocationIndexedLine) IndexOf(pt Coordinate) LinearLocation {
+	return locationOfPoint(l.pts, pt, nil)
+}
+
+// Finds the index for a point on the line
+// which is greater than the given index.
+// If no such index exists, returns minIndex.
+// This method can be used to determine all indexes for
+// a point which occurs more than once on a non-simple line.
+func (l *LocationIndexedLine) IndexOfAfter(pt Coordinate, minIndex LinearLocation) LinearLocation {
+	return locationOfPoint(l.pts, pt, &minIndex)
+}
+
+// Computes the index for the closest point on the line to the given point.
+// If more than one point has the closest distance the first one along the line
+// is returned.
+// (The point does not necessarily have to lie precisely on the line.)
+func (l *LocationIndexedLine) Project(pt Coordinate) LinearLocation {
+	return locationOfPoint(l.pts, pt, nil)
+}
+
+// Returns the index of the start of the line.
+func (l *LocationIndexedLine) GetStartIndex() LinearLocation {
+	return NewDefaultLinearLocation()
+}
+
+// Returns the index of the end of the line.
+func (l *LocationIndexedLine) GetEndIndex() LinearLocation {
+	return getEndLocation(l.pts)
+}
+
+// Tests whether an index is in the valid index range for the line.
+func (l *LocationIndexedLine) IsValidIndex(index LinearLocation) bool {
+	return index.isValid(l.pts)
+}
+
+// Computes a valid index for this line
+// by clamping the given index to the valid range of index values.
+func (l *LocationIndexedLine) ClampIndex(index LinearLocation) LinearLocation {
+	index.clamp(l.pts)
+	return index
+}
+
+// Computes the location of the point on the line closest to the given point.
+// If minIndex is given, only locations strictly after it are considered;
+// if there are none, minIndex is returned.
+func locationOfPoint(pts []Coordinate, pt Coordinate, minIndex *LinearLocation) LinearLocation {
+	if len(pts) < 2 {
+		return NewDefaultLinearLocation()
+	}
+	minDistance := math.MaxFloat64
+	found := false
+	result := NewDefaultLinearLocation()
+	for i := 0; i < len(pts)-1; i++ {
+		seg := NewLineSegment(pts[i], pts[i+1])
+		segDistance := seg.distance(pt)
+		candidate := LinearLocation{segmentIndex: i, segmentFraction: seg.segmentFraction(pt)}
+		if minIndex != nil && candidate.compareTo(*minIndex) <= 0 {
+			continue
+		}
+		if segDistance < minDistance {
+			result = candidate
+			minDistance = segDistance
+			found = true
+		}
+	}
+	if !found && minIndex != nil {
+		return *minIndex
+	}
+	result.normalize()
+	result.clamp(pts)
+	return result
+}
+
+// Extracts the subline of a line between two locations.
+// If the start location is after the end location
+// the computed line is reversed.
+func extractLineByLocation(pts []Coordinate, start, end LinearLocation) []Coordinate {
+	if len(pts) == 0 {
+		return make([]Coordinate, 0)
+	}
+	start.clamp(pts)
+	end.clamp(pts)
+	if end.compareTo(start) < 0 {
+		result := computeLinear(pts, end, start)
+		reverse(result)
+		return result
+	}
+	return computeLinear(pts, start, end)
+}
+
+// Assumes start <= end.
+func computeLinear(pts []Coordinate, start, end LinearLocation) []Coordinate {
+	result := []Coordinate{start.getCoordinate(pts)}
+	// the clamped locations always give a valid range of vertices
+	vertices, _ := extract(pts, start.segmentIndex+1, end.segmentIndex)
+	result = append(result, vertices...)
+	result = append(result, end.getCoordinate(pts))
+	result = removeRepeatedPoints(result)
+	// a zero-length subline is represented by a line with two equal points
+	if len(result) == 1 {
+		result = append(result, result[0])
+	}
+	return result
+}
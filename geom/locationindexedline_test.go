@@ -0,0 +1,59 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLocationIndexOf(t *testing.T) {
+	assert := assert2.New(t)
+	line := NewLocationIndexedLine(createLine(0, 0, 10, 0, 10, 10))
+	assert.Equal(NewLinearLocation(0, 0.5), line.IndexOf(NewXYCoordinate(5, 0)))
+	assert.Equal(NewLinearLocation(1, 0), line.IndexOf(NewXYCoordinate(10, 0)))
+	assert.Equal(line.GetEndIndex(), line.IndexOf(NewXYCoordinate(10, 10)))
+	assert.Equal(NewLinearLocation(1, 0.25), line.Project(NewXYCoordinate(15, 2.5)))
+}
+
+func TestLocationExtract(t *testing.T) {
+	line := NewLocationIndexedLine(createLine(0, 0, 10, 0, 10, 10))
+	checkPoint(NewXYCoordinate(10, 5), line.ExtractPoint(NewLinearLocation(1, 0.5)), t)
+	checkPoint(NewXYCoordinate(10, 10), line.ExtractPoint(NewLinearLocation(5, 0.5)), t)
+	checkLine(createLine(5, 0, 10, 0, 10, 5),
+		line.ExtractLine(NewLinearLocation(0, 0.5), NewLinearLocation(1, 0.5)), t)
+	checkLine(createLine(10, 5, 10, 0, 5, 0),
+		line.ExtractLine(NewLinearLocation(1, 0.5), NewLinearLocation(0, 0.5)), t)
+}
+
+func TestLocationExtractEmptyLine(t *testing.T) {
+	line := LocationIndexedLine{}
+	checkPoint(NewEmptyCoordinate(), line.ExtractPoint(NewLinearLocation(0, 0.5)), t)
+	checkPoint(NewEmptyCoordinate(), line.ExtractPointWithOffset(NewDefaultLinearLocation(), 1), t)
+}
+
+func TestLocationIndexOfAfter(t *testing.T) {
+	assert := assert2.New(t)
+	line := NewLocationIndexedLine(createLine(0, 0, 10, 0, 10, 10, 0, 0))
+	pt := NewXYCoordinate(0, 0)
+	first := line.IndexOf(pt)
+	assert.Equal(line.GetStartIndex(), first)
+	assert.Equal(line.GetEndIndex(), line.IndexOfAfter(pt, first))
+}
+
+func TestLinearLocationNormalize(t *testing.T) {
+	assert := assert2.New(t)
+	loc := NewLinearLocation(0, 1.0)
+	assert.Equal(1, loc.segmentIndex)
+	assert.Equal(0.0, loc.segmentFraction)
+	loc = NewLinearLocation(-1, 0.5)
+	assert.Equal(NewDefaultLinearLocation(), loc)
+}
+
+func TestLinearLocationIsValid(t *testing.T) {
+	assert := assert2.New(t)
+	line := NewLocationIndexedLine(createLine(0, 0, 10, 0))
+	assert.True(line.IsValidIndex(NewLinearLocation(0, 0.5)))
+	assert.True(line.IsValidIndex(NewLinearLocation(1, 0)))
+	assert.False(line.IsValidIndex(NewLinearLocation(1, 0.5)))
+	assert.False(line.IsValidIndex(NewLinearLocation(2, 0)))
+	assert.Equal(line.GetEndIndex(), line.ClampIndex(NewLinearLocation(3, 0.5)))
+}
@@ -0,0 +1,179 @@
+package geom
+
+import "sort"
+
+// Tests if a ring defined by an array of Coordinate(s) is
+// oriented counter-clockwise, using the sign of its signed area.
+// A ring with zero area (which is degenerate) is not counter-clockwise.
+func IsCCW(ring []Coordinate) bool {
+	return signedRingArea(ring) > 0
+}
+
+// Computes the signed area of a closed ring,
+// which is positive if the ring is counter-clockwise.
+func signedRingArea(ring []Coordinate) float64 {
+	sum := 0.0
+	for i := 1; i < len(ring); i++ {
+		sum += ring[i-1].x*ring[i].y - ring[i].x*ring[i-1].y
+	}
+	return sum / 2
+}
+
+// Converts a line to normal form.
+// A line is in normal form if its first point is not greater than its last point,
+// comparing the points from both ends inwards.
+// Returns a normalized copy; the input is not modified.
+func NormalizeLine(pts []Coordinate) []Coordinate {
+	result := copyDeepCoordinateArray(pts)
+	for i := 0; i < len(result)/2; i++ {
+		j := len(result) - 1 - i
+		// skip equal points on both ends
+		if !result[i].equals(&result[j]) {
+			if result[i].compareTo(result[j]) > 0 {
+				reverse(result)
+			}
+			return result
+		}
+	}
+	return result
+}
+
+// Converts a ring to normal form.
+// The ring starts (and ends) at its minimum coordinate,
+// and is oriented clockwise or counter-clockwise as requested.
+// Returns a normalized copy; the input is not modified.
+// An array which is not a ring is returned unchanged.
+func NormalizeRing(ring []Coordinate, clockwise bool) []Coordinate {
+	result := copyDeepCoordinateArray(ring)
+	if !isRing(result) {
+		return result
+	}
+	minIndex := 0
+	for i := 1; i < len(result)-1; i++ {
+		if result[i].compareTo(result[minIndex]) < 0 {
+			minIndex = i
+		}
+	}
+	// the array is a ring and minIndex lies within it, so this cannot fail
+	_ = scroll(result, minIndex, true)
+	if IsCCW(result) == clockwise {
+		reverse(result)
+	}
+	return result
+}
+
+// Converts a polygon, given as a list of rings with the shell first, to normal form.
+// The shell is oriented clockwise and the holes counter-clockwise,
+// every ring starts at its minimum coordinate,
+// and the holes are sorted.
+func NormalizePolygon(rings [][]Coordinate) [][]Coordinate {
+	result := make([][]Coordinate, len(rings))
+	for i, ring := range rings {
+		result[i] = NormalizeRing(ring, i == 0)
+	}
+	if len(result) > 1 {
+		holes := result[1:]
+		SortCoordinateArrays(holes, ForwardComparator{})
+	}
+	return result
+}
+
+// Converts a set of points to normal form, by sorting them.
+func NormalizePoints(pts []Coordinate) []Coordinate {
+	result := copyDeepCoordinateArray(pts)
+	sort.Stable(CoordinateSlice(result))
+	return result
+}
+
+// Converts a set of lines to normal form.
+// Each line is normalized, and the lines are sorted.
+func NormalizeLines(lines [][]Coordinate) [][]Coordinate {
+	result := make([][]Coordinate, len(lines))
+	for i, pts := range lines {
+		result[i] = NormalizeLine(pts)
+	}
+	SortCoordinateArrays(result, ForwardComparator{})
+	return result
+}
+
+// Converts a set of polygons to normal form.
+// Each polygon is normalized, and the polygons are sorted.
+func NormalizePolygons(polygons [][][]Coordinate) [][][]Coordinate {
+	result := make([][][]Coordinate, len(polygons))
+	for i, rings := range polygons {
+		result[i] = NormalizePolygon(rings)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return comparePolygons(result[i], result[j]) < 0
+	})
+	return result
+}
+
+// Compares two polygons, given as lists of rings with the shell first,
+// by their shells, then by their number of holes, then by their holes.
+func comparePolygons(rings1, rings2 [][]Coordinate) int {
+	if len(rings1) == 0 || len(rings2) == 0 {
+		return compareInts(len(rings1), len(rings2))
+	}
+	if comp := compare(rings1[0], rings2[0]); comp != 0 {
+		return comp
+	}
+	if comp := compareInts(len(rings1), len(rings2)); comp != 0 {
+		return comp
+	}
+	for i := 1; i < len(rings1); i++ {
+		if comp := compare(rings1[i], rings2[i]); comp != 0 {
+			return comp
+		}
+	}
+	return 0
+}
+
+// Tests whether two coordinate arrays are exactly equal, up to a tolerance.
+// The arrays must have the same number of points in the same order,
+// and each pair of points must be no further apart than the tolerance.
+func EqualsExact(pts1, pts2 []Coordinate, tolerance float64) bool {
+	if len(pts1) != len(pts2) {
+		return false
+	}
+	for i := range pts1 {
+		if !equalWithTolerance(pts1[i], pts2[i], tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalWithTolerance(a, b Coordinate, tolerance float64) bool {
+	if tolerance == 0 {
+		return a.equals(&b)
+	}
+	return a.distance(&b) <= tolerance
+}
+
+// Tests whether two polygons, given as lists of rings with the shell first,
+// are exactly equal up to a tolerance, ring by ring.
+func EqualsExactPolygon(rings1, rings2 [][]Coordinate, tolerance float64) bool {
+	if len(rings1) != len(rings2) {
+		return false
+	}
+	for i := range rings1 {
+		if !EqualsExact(rings1[i], rings2[i], tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// Tests whether two lines are equal after normalization,
+// that is whether they have the same points, possibly in reverse order.
+func EqualsNormLine(pts1, pts2 []Coordinate) bool {
+	return EqualsExact(NormalizeLine(pts1), NormalizeLine(pts2), 0)
+}
+
+// Tests whether two polygons are equal after normalization,
+// that is whether they have the same rings, regardless of the start point
+// and orientation of the rings and the order of the holes.
+func EqualsNormPolygon(rings1, rings2 [][]Coordinate) bool {
+	return EqualsExactPolygon(NormalizePolygon(rings1), NormalizePolygon(rings2), 0)
+}
@@ -0,0 +1,74 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsCCW(t *testing.T) {
+	assert2.True(t, IsCCW(createLine(0, 0, 1, 0, 1, 1, 0, 0)))
+	assert2.False(t, IsCCW(createLine(0, 0, 1, 1, 1, 0, 0, 0)))
+	assert2.False(t, IsCCW(createLine(0, 0, 1, 1, 2, 2, 0, 0)))
+}
+
+func TestNormalizeLine(t *testing.T) {
+	assert2.True(t, equalArrays(createLine(0, 0, 1, 1, 2, 0), NormalizeLine(createLine(2, 0, 1, 1, 0, 0))))
+	// equal end points are skipped
+	assert2.True(t, equalArrays(createLine(0, 0, 1, 1, 2, 2, 0, 0), NormalizeLine(createLine(0, 0, 2, 2, 1, 1, 0, 0))))
+	input := createLine(1, 0, 0, 0)
+	NormalizeLine(input)
+	assert2.True(t, equalArrays(createLine(1, 0, 0, 0), input))
+}
+
+func TestNormalizeRing(t *testing.T) {
+	ring := createLine(1, 1, 0, 1, 0, 0, 1, 0, 1, 1)
+	assert2.True(t, equalArrays(createLine(0, 0, 0, 1, 1, 1, 1, 0, 0, 0), NormalizeRing(ring, true)))
+	assert2.True(t, equalArrays(createLine(0, 0, 1, 0, 1, 1, 0, 1, 0, 0), NormalizeRing(ring, false)))
+}
+
+func TestNormalizePolygon(t *testing.T) {
+	polygon := [][]Coordinate{
+		createLine(10, 10, 10, 0, 0, 0, 0, 10, 10, 10),
+		createLine(6, 6, 7, 6, 7, 7, 6, 7, 6, 6),
+		createLine(2, 2, 2, 3, 3, 3, 2, 2),
+	}
+	normalized := NormalizePolygon(polygon)
+	assert2.True(t, equalArrays(createLine(0, 0, 0, 10, 10, 10, 10, 0, 0, 0), normalized[0]))
+	assert2.True(t, equalArrays(createLine(2, 2, 3, 3, 2, 3, 2, 2), normalized[1]))
+	assert2.True(t, equalArrays(createLine(6, 6, 7, 6, 7, 7, 6, 7, 6, 6), normalized[2]))
+
+	reordered := [][]Coordinate{
+		createLine(0, 10, 10, 10, 10, 0, 0, 0, 0, 10),
+		createLine(2, 3, 3, 3, 2, 2, 2, 3),
+		createLine(7, 7, 6, 7, 6, 6, 7, 6, 7, 7),
+	}
+	assert2.True(t, EqualsNormPolygon(polygon, reordered))
+	assert2.False(t, EqualsExactPolygon(polygon, reordered, 0))
+}
+
+func TestNormalizeCollections(t *testing.T) {
+	points := NormalizePoints(createLine(2, 2, 0, 1, 0, 0))
+	assert2.True(t, equalArrays(createLine(0, 0, 0, 1, 2, 2), points))
+
+	lines := NormalizeLines([][]Coordinate{createLine(5, 5, 4, 4), createLine(3, 0, 1, 1)})
+	assert2.True(t, equalArrays(createLine(1, 1, 3, 0), lines[0]))
+	assert2.True(t, equalArrays(createLine(4, 4, 5, 5), lines[1]))
+
+	polygons := NormalizePolygons([][][]Coordinate{
+		{createLine(5, 5, 6, 5, 6, 6, 5, 5)},
+		{createLine(0, 0, 1, 0, 1, 1, 0, 0)},
+	})
+	assert2.Equal(t, 0.0, polygons[0][0][0].x)
+	assert2.Equal(t, 5.0, polygons[1][0][0].x)
+}
+
+func TestEqualsExact(t *testing.T) {
+	line := createLine(0, 0, 1, 1)
+	assert2.True(t, EqualsExact(line, createLine(0, 0, 1, 1), 0))
+	assert2.False(t, EqualsExact(line, createLine(0, 0, 1, 1.1), 0))
+	assert2.True(t, EqualsExact(line, createLine(0, 0, 1, 1.1), 0.12))
+	assert2.False(t, EqualsExact(line, createLine(0, 0, 1.1, 1.1), 0.12))
+	assert2.False(t, EqualsExact(line, createLine(0, 0, 1, 1, 2, 2), 1))
+	assert2.True(t, EqualsNormLine(line, createLine(1, 1, 0, 0)))
+	assert2.False(t, EqualsExact(line, createLine(1, 1, 0, 0), 0))
+}
@@ -0,0 +1,322 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// The maximum number of candidate points tried for each point
+// required, before a points builder gives up.
+const maxAttemptsPerPoint = 1000
+
+// The region in which a points builder creates points:
+// an Envelope, optionally restricted to a polygonal mask.
+type pointsRegion struct {
+	extent Envelope
+	mask   [][]Coordinate
+}
+
+// Sets the extent in which the points are created.
+// Any mask is removed.
+func (r *pointsRegion) SetExtent(extent Envelope) {
+	r.extent = extent
+	r.mask = nil
+}
+
+// Sets a polygon, given as a list of rings with the shell first,
+// which the points are created in.
+// The extent is set to the envelope of the shell.
+// A shell which is not a ring gives an error wrapping ErrNotARing.
+func (r *pointsRegion) SetMask(rings [][]Coordinate) error {
+	for _, ring := range rings {
+		if !isRing(ring) {
+			pt := NewEmptyCoordinate()
+			if len(ring) > 0 {
+				pt = ring[0]
+			}
+			return NewCoordinateError(ErrNotARing, pt, "mask ring "+toLineStringWKT(ring...))
+		}
+	}
+	if len(rings) == 0 {
+		return NewCoordinateError(ErrNotARing, NewEmptyCoordinate(), "mask has no shell")
+	}
+	r.mask = rings
+	r.extent = envelope(rings[0])
+	return nil
+}
+
+// Tests whether a point lies in the region.
+func (r *pointsRegion) contains(p Coordinate) bool {
+	if !r.extent.covers(p.x, p.y) {
+		return false
+	}
+	if r.mask == nil {
+		return true
+	}
+	if !isPointInRing(p, r.mask[0]) {
+		return false
+	}
+	for _, hole := range r.mask[1:] {
+		if isPointInRing(p, hole) {
+			return false
+		}
+	}
+	return true
+}
+
+// Checks that the region can contain points.
+func (r *pointsRegion) checkExtent() error {
+	if r.extent.isNull() {
+		return fmt.Errorf("%w: Extent of points must be set", ErrEmpty)
+	}
+	return nil
+}
+
+// Checks that a number of points is not negative.
+func checkNumPoints(numPts int) error {
+	if numPts < 0 {
+		return invalidArgumentError("Number of points must not be negative: %d", numPts)
+	}
+	return nil
+}
+
+// Creates points from a candidate generator, keeping those
+// which lie in the region, until the required number is reached.
+func (r *pointsRegion) collectPoints(numPts int, candidate func() Coordinate) ([]Coordinate, error) {
+	if err := r.checkExtent(); err != nil {
+		return nil, err
+	}
+	pts := make([]Coordinate, 0, numPts)
+	for attempts := 0; len(pts) < numPts; attempts++ {
+		if attempts > maxAttemptsPerPoint*numPts {
+			return nil, invalidArgumentError("Unable to create points in the extent; the mask may have no area")
+		}
+		if p := candidate(); r.contains(p) {
+			pts = append(pts, p)
+		}
+	}
+	return pts, nil
+}
+
+// Creates random points uniformly distributed in an extent,
+// and optionally within a polygonal mask.
+// The points are determined by the seed, so the same seed
+// always creates the same points.
+type RandomPointsBuilder struct {
+	pointsRegion
+	numPts int
+	rnd    *rand.Rand
+}
+
+// Creates a builder of random points using a seed.
+func NewRandomPointsBuilder(seed int64) RandomPointsBuilder {
+	return RandomPointsBuilder{
+		pointsRegion: pointsRegion{extent: NewEmptyEnvelope()},
+		rnd:          rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Sets the number of points to create, which must not be negative.
+func (b *RandomPointsBuilder) SetNumPoints(numPts int) error {
+	if err := checkNumPoints(numPts); err != nil {
+		return err
+	}
+	b.numPts = numPts
+	return nil
+}
+
+// Creates the random points.
+func (b *RandomPointsBuilder) GetPoints() ([]Coordinate, error) {
+	return b.collectPoints(b.numPts, func() Coordinate {
+		return randomPointInEnvelope(b.rnd, b.extent)
+	})
+}
+
+// Creates a random point in an Envelope.
+func randomPointInEnvelope(rnd *rand.Rand, env Envelope) Coordinate {
+	return NewXYCoordinate(
+		env.minX+env.width()*rnd.Float64(),
+		env.minY+env.height()*rnd.Float64(),
+	)
+}
+
+// Creates random points in clusters in an extent,
+// and optionally within a polygonal mask.
+// The cluster centres are uniformly distributed in the region,
+// and the points of each cluster have a normal distribution
+// around the centre, with a standard deviation of the cluster radius.
+// Points falling outside the region are discarded.
+// The points are determined by the seed, so the same seed
+// always creates the same points.
+type ClusteredPointsBuilder struct {
+	pointsRegion
+	numPts        int
+	numClusters   int
+	clusterRadius float64
+	rnd           *rand.Rand
+}
+
+// Creates a builder of clustered points using a seed,
+// with a single cluster of radius 1.
+func NewClusteredPointsBuilder(seed int64) ClusteredPointsBuilder {
+	return ClusteredPointsBuilder{
+		pointsRegion:  pointsRegion{extent: NewEmptyEnvelope()},
+		numClusters:   1,
+		clusterRadius: 1,
+		rnd:           rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Sets the number of points to create, which must not be negative.
+func (b *ClusteredPointsBuilder) SetNumPoints(numPts int) error {
+	if err := checkNumPoints(numPts); err != nil {
+		return err
+	}
+	b.numPts = numPts
+	return nil
+}
+
+// Sets the number of clusters, which must be at least 1.
+func (b *ClusteredPointsBuilder) SetNumClusters(numClusters int) error {
+	if numClusters < 1 {
+		return invalidArgumentError("Number of clusters must be at least 1: %d", numClusters)
+	}
+	b.numClusters = numClusters
+	return nil
+}
+
+// Sets the radius of the clusters, which is the standard
+// deviation of the distance of the points from their cluster centre.
+// The radius must be finite and not negative.
+func (b *ClusteredPointsBuilder) SetClusterRadius(clusterRadius float64) error {
+	if !(clusterRadius >= 0) || math.IsInf(clusterRadius, 1) {
+		return invalidArgumentError("Cluster radius must be finite and not negative: %g", clusterRadius)
+	}
+	b.clusterRadius = clusterRadius
+	return nil
+}
+
+// Creates the clustered points.
+func (b *ClusteredPointsBuilder) GetPoints() ([]Coordinate, error) {
+	centres, err := b.collectPoints(b.numClusters, func() Coordinate {
+		return randomPointInEnvelope(b.rnd, b.extent)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.collectPoints(b.numPts, func() Coordinate {
+		centre := centres[b.rnd.Intn(len(centres))]
+		return NewXYCoordinate(
+			centre.x+b.rnd.NormFloat64()*b.clusterRadius,
+			centre.y+b.rnd.NormFloat64()*b.clusterRadius,
+		)
+	})
+}
+
+// Creates quasi-random points in an extent, and optionally within a
+// polygonal mask, using the Halton sequence with bases 2 and 3.
+// The points cover the region more evenly than random points,
+// which makes them well suited for sampling.
+// The sequence is deterministic; different sets of points can be created
+// by starting at different indexes of the sequence.
+type HaltonPointsBuilder struct {
+	pointsRegion
+	numPts     int
+	startIndex int
+}
+
+// Creates a builder of Halton points, starting at the first
+// index of the sequence.
+func NewHaltonPointsBuilder() HaltonPointsBuilder {
+	return HaltonPointsBuilder{
+		pointsRegion: pointsRegion{extent: NewEmptyEnvelope()},
+		startIndex:   1,
+	}
+}
+
+// Sets the number of points to create, which must not be negative.
+func (b *HaltonPointsBuilder) SetNumPoints(numPts int) error {
+	if err := checkNumPoints(numPts); err != nil {
+		return err
+	}
+	b.numPts = numPts
+	return nil
+}
+
+// Sets the index of the Halton sequence at which the points start.
+// Index 0 is the origin of the extent, so the default is 1.
+func (b *HaltonPointsBuilder) SetStartIndex(startIndex int) {
+	b.startIndex = startIndex
+}
+
+// Creates the Halton points.
+func (b *HaltonPointsBuilder) GetPoints() ([]Coordinate, error) {
+	index := b.startIndex
+	return b.collectPoints(b.numPts, func() Coordinate {
+		p := NewXYCoordinate(
+			b.extent.minX+b.extent.width()*haltonValue(index, 2),
+			b.extent.minY+b.extent.height()*haltonValue(index, 3),
+		)
+		index++
+		return p
+	})
+}
+
+// Computes the value of the Halton sequence for a base at an index,
+// which is the radical inverse of the index in the base.
+func haltonValue(index, base int) float64 {
+	result := 0.0
+	f := 1.0
+	for i := index; i > 0; i /= base {
+		f /= float64(base)
+		result += f * float64(i%base)
+	}
+	return result
+}
+
+// Creates the points of a regular grid in an extent, and optionally
+// within a polygonal mask.
+// The grid is aligned to a FIXED PrecisionModel, so that the points
+// lie exactly on its grid, spaced by its grid size.
+type GridPointsBuilder struct {
+	pointsRegion
+	pm PrecisionModel
+}
+
+// Creates a builder of grid points aligned to a PrecisionModel,
+// which must be FIXED.
+func NewGridPointsBuilder(pm PrecisionModel) (GridPointsBuilder, error) {
+	if pm.modelType != FIXED {
+		return GridPointsBuilder{}, invalidArgumentError("Grid points require a FIXED precision model")
+	}
+	return GridPointsBuilder{
+		pointsRegion: pointsRegion{extent: NewEmptyEnvelope()},
+		pm:           pm,
+	}, nil
+}
+
+// Creates the grid points in the region,
+// in order of increasing Y and then X.
+func (b *GridPointsBuilder) GetPoints() ([]Coordinate, error) {
+	if err := b.checkExtent(); err != nil {
+		return nil, err
+	}
+	gridSize := b.pm.getGridSize()
+	// the grid indexes of the first and last points in the extent
+	minI := math.Ceil((b.extent.minX - b.pm.offsetX) / gridSize)
+	maxI := math.Floor((b.extent.maxX - b.pm.offsetX) / gridSize)
+	minJ := math.Ceil((b.extent.minY - b.pm.offsetY) / gridSize)
+	maxJ := math.Floor((b.extent.maxY - b.pm.offsetY) / gridSize)
+	pts := make([]Coordinate, 0)
+	for j := minJ; j <= maxJ; j++ {
+		for i := minI; i <= maxI; i++ {
+			p := NewXYCoordinate(b.pm.offsetX+i*gridSize, b.pm.offsetY+j*gridSize)
+			b.pm.makePreciseCoordinate(&p)
+			if b.contains(p) {
+				pts = append(pts, p)
+			}
+		}
+	}
+	return pts, nil
+}
@@ -0,0 +1,144 @@
+package geom
+
+import (
+	"errors"
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestRandomPointsBuilder(t *testing.T) {
+	b := NewRandomPointsBuilder(42)
+	b.SetExtent(NewEnvelope(0, 10, 0, 5))
+	b.SetNumPoints(100)
+	pts, err := b.GetPoints()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 100, len(pts))
+	env := NewEnvelope(0, 10, 0, 5)
+	for _, p := range pts {
+		assert2.True(t, env.coversCoordinate(p))
+	}
+
+	// the same seed gives the same points
+	other := NewRandomPointsBuilder(42)
+	other.SetExtent(NewEnvelope(0, 10, 0, 5))
+	other.SetNumPoints(100)
+	otherPts, _ := other.GetPoints()
+	assert2.True(t, equalArrays(pts, otherPts))
+
+	different := NewRandomPointsBuilder(7)
+	different.SetExtent(NewEnvelope(0, 10, 0, 5))
+	different.SetNumPoints(100)
+	differentPts, _ := different.GetPoints()
+	assert2.False(t, equalArrays(pts, differentPts))
+}
+
+func TestRandomPointsBuilderMask(t *testing.T) {
+	mask := [][]Coordinate{
+		createLine(0, 0, 10, 0, 10, 10, 0, 10, 0, 0),
+		createLine(2, 2, 2, 8, 8, 8, 8, 2, 2, 2),
+	}
+	b := NewRandomPointsBuilder(1)
+	assert2.Nil(t, b.SetMask(mask))
+	b.SetNumPoints(200)
+	pts, err := b.GetPoints()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 200, len(pts))
+	for _, p := range pts {
+		assert2.False(t, p.x > 2 && p.x < 8 && p.y > 2 && p.y < 8)
+	}
+
+	err = b.SetMask([][]Coordinate{createLine(0, 0, 1, 0, 1, 1)})
+	assert2.True(t, errors.Is(err, ErrNotARing))
+
+	// a mask with no area
+	assert2.Nil(t, b.SetMask([][]Coordinate{createLine(0, 0, 1, 1, 2, 2, 0, 0)}))
+	_, err = b.GetPoints()
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+
+	unset := NewRandomPointsBuilder(1)
+	unset.SetNumPoints(1)
+	_, err = unset.GetPoints()
+	assert2.True(t, errors.Is(err, ErrEmpty))
+}
+
+func TestClusteredPointsBuilder(t *testing.T) {
+	b := NewClusteredPointsBuilder(3)
+	b.SetExtent(NewEnvelope(0, 100, 0, 100))
+	b.SetNumPoints(300)
+	assert2.Nil(t, b.SetNumClusters(3))
+	b.SetClusterRadius(1)
+	pts, err := b.GetPoints()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 300, len(pts))
+	// the points are in at most 3 groups, so their grid cells are few
+	cells := make(map[CoordinateKey]bool)
+	for _, p := range pts {
+		cells[CoordinateKey{math.Floor(p.x / 20), math.Floor(p.y / 20)}] = true
+	}
+	assert2.True(t, len(cells) <= 12)
+
+	assert2.True(t, errors.Is(b.SetNumClusters(0), ErrInvalidArgument))
+	for _, radius := range []float64{-1, math.NaN(), math.Inf(1)} {
+		assert2.True(t, errors.Is(b.SetClusterRadius(radius), ErrInvalidArgument), radius)
+	}
+}
+
+func TestNegativeNumPoints(t *testing.T) {
+	random := NewRandomPointsBuilder(1)
+	assert2.True(t, errors.Is(random.SetNumPoints(-1), ErrInvalidArgument))
+	clustered := NewClusteredPointsBuilder(1)
+	assert2.True(t, errors.Is(clustered.SetNumPoints(-1), ErrInvalidArgument))
+	halton := NewHaltonPointsBuilder()
+	assert2.True(t, errors.Is(halton.SetNumPoints(-1), ErrInvalidArgument))
+
+	// the number of points is unchanged
+	halton.SetExtent(NewEnvelope(0, 1, 0, 1))
+	pts, err := halton.GetPoints()
+	assert2.Nil(t, err)
+	assert2.Empty(t, pts)
+}
+
+func TestHaltonPointsBuilder(t *testing.T) {
+	assert2.Equal(t, 0.5, haltonValue(1, 2))
+	assert2.Equal(t, 0.75, haltonValue(3, 2))
+	assert2.InDelta(t, 7.0/9, haltonValue(5, 3), 1e-15)
+
+	b := NewHaltonPointsBuilder()
+	b.SetExtent(NewEnvelope(0, 1, 0, 1))
+	b.SetNumPoints(1000)
+	pts, err := b.GetPoints()
+	assert2.Nil(t, err)
+	assert2.True(t, pts[0].equals2D(&Coordinate{x: 0.5, y: 1.0 / 3}))
+	// a Monte-Carlo estimate of the area of the unit quarter circle
+	inside := 0
+	for _, p := range pts {
+		if p.x*p.x+p.y*p.y <= 1 {
+			inside++
+		}
+	}
+	assert2.InDelta(t, math.Pi/4, float64(inside)/float64(len(pts)), 0.01)
+
+	b.SetStartIndex(1000)
+	b.SetNumPoints(1)
+	later, _ := b.GetPoints()
+	assert2.False(t, later[0].equals2D(&pts[0]))
+}
+
+func TestGridPointsBuilder(t *testing.T) {
+	b, err := NewGridPointsBuilder(mustFixedPrecisionModel(2))
+	assert2.Nil(t, err)
+	b.SetExtent(NewEnvelope(0.2, 1.4, -0.5, 0.5))
+	pts, err := b.GetPoints()
+	assert2.Nil(t, err)
+	assert2.True(t, equalArrays(createLine(0.5, -0.5, 1, -0.5, 0.5, 0, 1, 0, 0.5, 0.5, 1, 0.5), pts))
+
+	assert2.Nil(t, b.SetMask([][]Coordinate{createLine(0, 0, 2, 0, 0, 2, 0, 0)}))
+	pts, _ = b.GetPoints()
+	for _, p := range pts {
+		assert2.True(t, p.x+p.y < 2)
+	}
+
+	_, err = NewGridPointsBuilder(NewDefaultPrecisionModel())
+	assert2.True(t, errors.Is(err, ErrInvalidArgument))
+}
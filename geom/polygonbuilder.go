@@ -0,0 +1,223 @@
+package geom
+
+import (
+	"math"
+	"sort"
+)
+
+// A directed edge of a noded arrangement of rings.
+type polygonEdge struct {
+	from Coordinate
+	to   Coordinate
+	used bool
+}
+
+// Gets the angle of the edge direction, in radians in the range (-Pi, Pi].
+func (e *polygonEdge) angle() float64 {
+	return math.Atan2(e.to.y-e.from.y, e.to.x-e.from.x)
+}
+
+// Builds valid polygons from a set of fully noded rings,
+// oriented so that the interior of the area is on the left
+// (shells counter-clockwise and holes clockwise).
+//
+// Edges which are traversed in both directions cancel out,
+// which removes collapsed spikes and zero-width gores.
+// The remaining edges are linked into rings, which are split at
+// self-touching vertices. Counter-clockwise rings form the shells of the
+// result and clockwise rings are assigned as holes of the
+// smallest shell containing them; holes not contained in any shell are dropped.
+//
+// Each polygon is returned as a list of closed rings with the shell first.
+// If the edges cannot be linked into closed rings, which indicates that the
+// input is not fully noded, a TopologyError recording the rings is returned.
+func buildPolygons(rings [][]Coordinate) ([][][]Coordinate, error) {
+	edges := netEdges(rings)
+	outEdges := make(map[CoordinateKey][]*polygonEdge)
+	for _, e := range edges {
+		key := CoordinateKey{e.from.x, e.from.y}
+		outEdges[key] = append(outEdges[key], e)
+	}
+
+	var shells [][]Coordinate
+	var holes [][]Coordinate
+	for _, e := range edges {
+		if e.used {
+			continue
+		}
+		traced, err := traceRing(e, outEdges)
+		if err != nil {
+			return nil, err.addInputLines(rings...)
+		}
+		for _, ring := range splitRing(traced) {
+			if signedRingArea(ring) > 0 {
+				shells = append(shells, ring)
+			} else if signedRingArea(ring) < 0 {
+				holes = append(holes, ring)
+			}
+		}
+	}
+	return assignHoles(shells, holes), nil
+}
+
+// Computes the directed edges of the rings which are not
+// cancelled out by an edge in the opposite direction.
+// The edges are returned in the order in which they first occur.
+func netEdges(rings [][]Coordinate) []*polygonEdge {
+	count := make(map[hullEdgeKey]int)
+	var keys []hullEdgeKey
+	var firsts []*polygonEdge
+	for _, ring := range rings {
+		for i := 1; i < len(ring); i++ {
+			p0 := ring[i-1]
+			p1 := ring[i]
+			if p0.equals2D(&p1) {
+				continue
+			}
+			k0 := CoordinateKey{p0.x, p0.y}
+			k1 := CoordinateKey{p1.x, p1.y}
+			// count edges in the direction of increasing coordinates
+			key := hullEdgeKey{k0, k1}
+			delta := 1
+			if p0.compareTo(p1) > 0 {
+				key = hullEdgeKey{k1, k0}
+				delta = -1
+			}
+			if _, ok := count[key]; !ok {
+				keys = append(keys, key)
+				firsts = append(firsts, &polygonEdge{from: p0, to: p1})
+			}
+			count[key] += delta
+		}
+	}
+	var edges []*polygonEdge
+	for i, key := range keys {
+		n := count[key]
+		if n == 0 {
+			continue
+		}
+		e := firsts[i]
+		// orient the edge in the direction of the net count
+		isIncreasing := e.from.compareTo(e.to) < 0
+		if (n > 0) != isIncreasing {
+			e.from, e.to = e.to, e.from
+		}
+		edges = append(edges, e)
+	}
+	return edges
+}
+
+// Traces a closed ring starting with an edge.
+// At each node the next edge is the unused outgoing edge which makes the
+// sharpest clockwise turn from the reverse of the incoming edge,
+// which keeps the traced face on the left.
+// Reaching a node with no unused outgoing edge gives a TopologyError.
+func traceRing(start *polygonEdge, outEdges map[CoordinateKey][]*polygonEdge) ([]Coordinate, *TopologyError) {
+	ring := []Coordinate{start.from}
+	e := start
+	for {
+		e.used = true
+		ring = append(ring, e.to)
+		if e.to.equals2D(&start.from) {
+			return ring, nil
+		}
+		backAngle := math.Atan2(e.from.y-e.to.y, e.from.x-e.to.x)
+		var next *polygonEdge
+		bestTurn := 0.0
+		for _, cand := range outEdges[CoordinateKey{e.to.x, e.to.y}] {
+			if cand.used {
+				continue
+			}
+			turn := backAngle - cand.angle()
+			for turn <= 0 {
+				turn += 2 * math.Pi
+			}
+			if next == nil || turn < bestTurn {
+				next = cand
+				bestTurn = turn
+			}
+		}
+		if next == nil {
+			return nil, NewTopologyError("polygon building", e.to, "ring is not closed")
+		}
+		e = next
+	}
+}
+
+// Splits a closed ring at its self-touching vertices
+// into a set of simple closed rings.
+func splitRing(ring []Coordinate) [][]Coordinate {
+	var result [][]Coordinate
+	var path []Coordinate
+	index := make(map[CoordinateKey]int)
+	for _, p := range ring {
+		key := CoordinateKey{p.x, p.y}
+		if i, ok := index[key]; ok {
+			loop := make([]Coordinate, len(path)-i, len(path)-i+1)
+			copy(loop, path[i:])
+			loop = append(loop, p)
+			if len(loop) >= 4 {
+				result = append(result, loop)
+			}
+			for _, q := range path[i+1:] {
+				delete(index, CoordinateKey{q.x, q.y})
+			}
+			path = path[:i+1]
+			continue
+		}
+		index[key] = len(path)
+		path = append(path, p)
+	}
+	return result
+}
+
+// Assigns each hole to the smallest shell which contains it.
+func assignHoles(shells, holes [][]Coordinate) [][][]Coordinate {
+	// sort shells by increasing area, so the first containing shell is the smallest
+	sort.SliceStable(shells, func(i, j int) bool {
+		return signedRingArea(shells[i]) < signedRingArea(shells[j])
+	})
+	polygons := make([][][]Coordinate, len(shells))
+	for i, shell := range shells {
+		polygons[i] = [][]Coordinate{shell}
+	}
+	for _, hole := range holes {
+		for i, shell := range shells {
+			if isRingInShell(hole, shell) {
+				polygons[i] = append(polygons[i], hole)
+				break
+			}
+		}
+	}
+	return polygons
+}
+
+// Tests whether a hole lies inside a shell, using a
+// vertex of the hole which is not a vertex of the shell,
+// or the midpoint of an edge of the hole if there is none.
+func isRingInShell(hole, shell []Coordinate) bool {
+	for _, p := range hole {
+		if indexOf(p, shell) < 0 {
+			return isPointInRing(p, shell)
+		}
+	}
+	for i := 1; i < len(hole); i++ {
+		seg := NewLineSegment(hole[i-1], hole[i])
+		mid := seg.midPoint()
+		if !isPointOnRing(mid, shell) {
+			return isPointInRing(mid, shell)
+		}
+	}
+	return false
+}
+
+// Tests whether a point lies on a segment of a ring.
+func isPointOnRing(p Coordinate, ring []Coordinate) bool {
+	for i := 1; i < len(ring); i++ {
+		seg := NewLineSegment(ring[i-1], ring[i])
+		if seg.distance(p) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -1,13 +1,39 @@
 package geom
 
-import "math"
+import (
+	"math"
+	"strconv"
+	"strings"
+)
 
+// The types of PrecisionModel which can be specified.
 type ModelType string
 
 const (
-	FIXED           ModelType = "FIXED"
-	FLOATING                  = "FLOATING"
-	FLOATING_SINGLE           = "FLOATING_SINGLE"
+	// Fixed Precision indicates that coordinates have a fixed number of decimal places.
+	// The number of decimal places is determined by the log10 of the scale factor.
+	FIXED ModelType = "FIXED"
+	// Floating precision corresponds to the standard Go
+	// double-precision floating-point representation, which is
+	// based on the IEEE-754 standard
+	FLOATING ModelType = "FLOATING"
+	// Floating single precision corresponds to the standard Go
+	// single-precision floating-point representation, which is
+	// based on the IEEE-754 standard
+	FLOATING_SINGLE ModelType = "FLOATING_SINGLE"
+)
+
+// The rule used to round values which lie exactly halfway between
+// two points of a FIXED precision grid.
+type RoundingMode string
+
+const (
+	// Rounds halfway values towards positive infinity,
+	// as Java's Math.round does. This is the default.
+	HALF_UP RoundingMode = "HALF_UP"
+	// Rounds halfway values to the even neighbour (banker's rounding),
+	// which avoids a bias when many values are rounded.
+	HALF_EVEN RoundingMode = "HALF_EVEN"
 )
 
 // Specifies the precision model of the Coordinate(s) in a Geometry.
@@ -36,6 +62,9 @@ const (
 //
 // 	A Fixed Precision Model is specified by a scale factor.
 //  The scale factor specifies the size of the grid which numbers are rounded to.
+//  Alternatively the size of the grid can be given directly
+//  (see NewPrecisionModelFromGridSize), which is more accurate
+//  for grids coarser than 1.
 //  Input coordinates are mapped to fixed coordinates according to the following
 //  equations:
 //
@@ -54,9 +83,21 @@ const (
 // <p>
 // JTS binary methods currently do not handle inputs which have different precision models.
 // The precision model of any constructed geometric value is undefined.
+//
+// Halfway values are rounded up by default; banker's rounding can be selected
+// with SetRoundingMode. A FIXED model may also have an offset,
+// which moves the origin of the grid.
+//
+// The String form of a PrecisionModel can be read back with ParsePrecisionModel,
+// which allows precision models to be persisted in configuration and metadata.
 type PrecisionModel struct {
 	modelType ModelType
 	scale     float64
+	// the grid size, if it was specified explicitly (otherwise 0)
+	gridSize     float64
+	offsetX      float64
+	offsetY      float64
+	roundingMode RoundingMode
 }
 
 // Determines which of two PrecisionModel is the most precise
@@ -72,7 +113,8 @@ func mostPrecise(pm1, pm2 *PrecisionModel) *PrecisionModel {
 // of FLOATING.
 func NewDefaultPrecisionModel() PrecisionModel {
 	return PrecisionModel{
-		modelType: FLOATING,
+		modelType:    FLOATING,
+		roundingMode: HALF_UP,
 	}
 }
 
@@ -81,7 +123,8 @@ func NewDefaultPrecisionModel() PrecisionModel {
 // If the model type is FIXED the scale factor will default to 1.
 func NewPrecisionModel(modelType ModelType) PrecisionModel {
 	result := PrecisionModel{
-		modelType: modelType,
+		modelType:    modelType,
+		roundingMode: HALF_UP,
 	}
 	if modelType == FIXED {
 		result.setScale(1.0)
@@ -92,17 +135,104 @@ func NewPrecisionModel(modelType ModelType) PrecisionModel {
 //  Creates a PrecisionModel that specifies Fixed precision.
 //  Fixed-precision coordinates are represented as precise internal coordinates,
 //  which are rounded to the grid defined by the scale factor.
-func NewFixedPrecisionModel(scale float64) PrecisionModel {
+//  The scale must be positive and finite; otherwise an error wrapping
+//  ErrInvalidArgument is returned.
+func NewFixedPrecisionModel(scale float64) (PrecisionModel, error) {
+	if err := checkPrecisionValue("scale", scale); err != nil {
+		return PrecisionModel{}, err
+	}
 	result := PrecisionModel{
-		modelType: FIXED,
+		modelType:    FIXED,
+		roundingMode: HALF_UP,
 	}
 	result.setScale(scale)
-	return result
+	return result, nil
+}
+
+// Creates a PrecisionModel that specifies Fixed precision
+// with a grid of the given size.
+// For example, a grid size of 1000 rounds to the nearest 1000.
+// The grid size must be positive and finite.
+func NewPrecisionModelFromGridSize(gridSize float64) (PrecisionModel, error) {
+	if err := checkPrecisionValue("grid size", gridSize); err != nil {
+		return PrecisionModel{}, err
+	}
+	result := PrecisionModel{
+		modelType:    FIXED,
+		roundingMode: HALF_UP,
+	}
+	result.setScale(-gridSize)
+	return result, nil
+}
+
+// Creates a PrecisionModel that specifies Fixed precision,
+// with the origin of the grid moved to the given offset.
+// Coordinates are rounded so that their distance from the offset
+// is a multiple of the grid size.
+func NewFixedPrecisionModelWithOffset(scale, offsetX, offsetY float64) (PrecisionModel, error) {
+	result, err := NewFixedPrecisionModel(scale)
+	if err != nil {
+		return PrecisionModel{}, err
+	}
+	result.offsetX = offsetX
+	result.offsetY = offsetY
+	return result, nil
+}
+
+// Checks that a scale or grid size is positive and finite,
+// since rounding to a grid of zero or infinite size gives NaN.
+func checkPrecisionValue(name string, value float64) error {
+	if !(value > 0) || math.IsInf(value, 1) {
+		return invalidArgumentError("Precision model %s must be positive and finite: %g", name, value)
+	}
+	return nil
 }
 
 //  Sets the multiplying factor used to obtain a precise coordinate.
+//  A negative scale indicates the grid size is being set.
 func (p *PrecisionModel) setScale(scale float64) {
-	p.scale = math.Abs(scale)
+	if scale < 0 {
+		p.gridSize = math.Abs(scale)
+		p.scale = 1.0 / p.gridSize
+	} else {
+		p.scale = math.Abs(scale)
+		p.gridSize = 0.0
+	}
+}
+
+// Sets the rule used to round halfway values in a FIXED model.
+func (p *PrecisionModel) SetRoundingMode(roundingMode RoundingMode) {
+	p.roundingMode = roundingMode
+}
+
+// Gets the type of this precision model
+func (p *PrecisionModel) getType() ModelType {
+	return p.modelType
+}
+
+// Returns the scale factor used to specify a fixed precision model.
+// The number of decimal places of precision is
+// equal to the base-10 logarithm of the scale factor.
+// Non-integral and negative scale factors are supported.
+// Negative scale factors indicate that the places
+// of precision is to the left of the decimal point.
+func (p *PrecisionModel) getScale() float64 {
+	return p.scale
+}
+
+// Computes the grid size for a fixed precision model.
+// This is equal to the reciprocal of the scale factor.
+// If the grid size has been set explicitly (via a negative scale factor)
+// it will be returned.
+// Returns NaN for floating models.
+func (p *PrecisionModel) getGridSize() float64 {
+	if p.isFloating() {
+		return math.NaN()
+	}
+	if p.gridSize != 0 {
+		return p.gridSize
+	}
+	return 1.0 / p.scale
 }
 
 // Tests whether the precision model supports floating point
@@ -140,12 +270,19 @@ func (p *PrecisionModel) getMaximumSignificantDigits() int {
 }
 
 // Rounds a numeric value to the PrecisionModel grid.
-// Asymmetric Arithmetic Rounding is used, to provide
+// By default Asymmetric Arithmetic Rounding is used, to provide
 // uniform rounding behaviour no matter where the number is
 // on the number line.
+// The grid offset is not applied; it only affects coordinates.
 //
 // This method has no effect on NaN values.
 func (p *PrecisionModel) makePrecise(value float64) float64 {
+	return p.makePreciseWithOffset(value, 0)
+}
+
+// Rounds a numeric value to the PrecisionModel grid
+// with its origin moved to an offset.
+func (p *PrecisionModel) makePreciseWithOffset(value, offset float64) float64 {
 	if math.IsNaN(value) {
 		return value
 	}
@@ -154,12 +291,34 @@ func (p *PrecisionModel) makePrecise(value float64) float64 {
 		return float64(floatSingleValue)
 	}
 	if p.modelType == FIXED {
-		return math.Round(value*p.scale) / p.scale
+		value -= offset
+		var rounded float64
+		// a grid size larger than 1 is more accurate than its reciprocal
+		if p.gridSize > 1 {
+			rounded = p.round(value/p.gridSize) * p.gridSize
+		} else {
+			rounded = p.round(value*p.scale) / p.scale
+		}
+		return rounded + offset
 	}
 	// modelType == FLOATING - no rounding necessary
 	return value
 }
 
+// Rounds a value to an integer, using the rounding mode.
+func (p *PrecisionModel) round(value float64) float64 {
+	if p.roundingMode == HALF_EVEN {
+		return math.RoundToEven(value)
+	}
+	// round half up, as Java's Math.round does.
+	// Subtracting the floor is exact, so values just below 0.5 round down
+	floor := math.Floor(value)
+	if value-floor >= 0.5 {
+		return floor + 1
+	}
+	return floor
+}
+
 // Rounds a Coordinate to the PrecisionModel grid.
 // Modifies the Coordinate
 func (p *PrecisionModel) makePreciseCoordinate(coordinate *Coordinate) {
@@ -167,8 +326,8 @@ func (p *PrecisionModel) makePreciseCoordinate(coordinate *Coordinate) {
 		return
 	}
 
-	coordinate.x = p.makePrecise(coordinate.x)
-	coordinate.y = p.makePrecise(coordinate.y)
+	coordinate.x = p.makePreciseWithOffset(coordinate.x, p.offsetX)
+	coordinate.y = p.makePreciseWithOffset(coordinate.y, p.offsetY)
 
 	//MD says it's OK that we're not makePrecise'ing the z [Jon Aquino]
 }
@@ -190,3 +349,137 @@ func (p *PrecisionModel) compareTo(other *PrecisionModel) int {
 	}
 	return 0
 }
+
+// Tests whether another PrecisionModel specifies the same grid
+// and rounding as this one.
+func (p *PrecisionModel) Equals(other PrecisionModel) bool {
+	if p.modelType != other.modelType {
+		return false
+	}
+	if p.modelType != FIXED {
+		return true
+	}
+	return p.scale == other.scale &&
+		p.gridSize == other.gridSize &&
+		p.offsetX == other.offsetX &&
+		p.offsetY == other.offsetY &&
+		p.effectiveRoundingMode() == other.effectiveRoundingMode()
+}
+
+func (p *PrecisionModel) effectiveRoundingMode() RoundingMode {
+	if p.roundingMode == "" {
+		return HALF_UP
+	}
+	return p.roundingMode
+}
+
+// Gets a description of the precision model, such as
+// "Floating", "Floating-Single" or "Fixed (Scale=1000)".
+// The description can be read back with ParsePrecisionModel.
+func (p PrecisionModel) String() string {
+	switch p.modelType {
+	case FLOATING:
+		return "Floating"
+	case FLOATING_SINGLE:
+		return "Floating-Single"
+	case FIXED:
+		var params []string
+		if p.gridSize != 0 {
+			params = append(params, "GridSize="+formatPrecisionValue(p.gridSize))
+		} else {
+			params = append(params, "Scale="+formatPrecisionValue(p.scale))
+		}
+		if p.offsetX != 0 || p.offsetY != 0 {
+			params = append(params,
+				"OffsetX="+formatPrecisionValue(p.offsetX),
+				"OffsetY="+formatPrecisionValue(p.offsetY))
+		}
+		if p.effectiveRoundingMode() != HALF_UP {
+			params = append(params, "Rounding="+string(p.roundingMode))
+		}
+		return "Fixed (" + strings.Join(params, ", ") + ")"
+	}
+	return "UNKNOWN"
+}
+
+func formatPrecisionValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// Parses a PrecisionModel from its String form.
+// The model type may also be given by its ModelType name
+// (e.g. "FIXED" or "FLOATING_SINGLE"), and is matched case-insensitively.
+// A FIXED model accepts the parameters Scale, GridSize, OffsetX, OffsetY
+// and Rounding in parentheses; without parameters its scale is 1.
+func ParsePrecisionModel(s string) (PrecisionModel, error) {
+	name := strings.TrimSpace(s)
+	params := ""
+	if i := strings.Index(name, "("); i >= 0 {
+		if !strings.HasSuffix(name, ")") {
+			return PrecisionModel{}, invalidArgumentError("Invalid precision model: %s", s)
+		}
+		params = name[i+1 : len(name)-1]
+		name = strings.TrimSpace(name[:i])
+	}
+	modelType := ModelType(strings.ReplaceAll(strings.ToUpper(name), "-", "_"))
+	switch modelType {
+	case FLOATING, FLOATING_SINGLE:
+		if strings.TrimSpace(params) != "" {
+			return PrecisionModel{}, invalidArgumentError("Floating precision models have no parameters: %s", s)
+		}
+		return NewPrecisionModel(modelType), nil
+	case FIXED:
+		return parseFixedPrecisionModel(params)
+	}
+	return PrecisionModel{}, invalidArgumentError("Unknown precision model type: %s", s)
+}
+
+func parseFixedPrecisionModel(params string) (PrecisionModel, error) {
+	result := NewPrecisionModel(FIXED)
+	hasScale := false
+	for _, param := range strings.Split(params, ",") {
+		if strings.TrimSpace(param) == "" {
+			continue
+		}
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			return PrecisionModel{}, invalidArgumentError("Invalid precision model parameter: %s", param)
+		}
+		key := strings.ToUpper(strings.TrimSpace(kv[0]))
+		value := strings.TrimSpace(kv[1])
+		if key == "ROUNDING" {
+			mode := RoundingMode(strings.ToUpper(value))
+			if mode != HALF_UP && mode != HALF_EVEN {
+				return PrecisionModel{}, invalidArgumentError("Unknown rounding mode: %s", value)
+			}
+			result.roundingMode = mode
+			continue
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return PrecisionModel{}, invalidArgumentError("Invalid precision model parameter: %s", param)
+		}
+		switch key {
+		case "SCALE", "GRIDSIZE":
+			if hasScale {
+				return PrecisionModel{}, invalidArgumentError("Only one of Scale and GridSize may be given")
+			}
+			if checkPrecisionValue("scale", number) != nil {
+				return PrecisionModel{}, invalidArgumentError("Invalid precision model parameter: %s", param)
+			}
+			hasScale = true
+			if key == "SCALE" {
+				result.setScale(number)
+			} else {
+				result.setScale(-number)
+			}
+		case "OFFSETX":
+			result.offsetX = number
+		case "OFFSETY":
+			result.offsetY = number
+		default:
+			return PrecisionModel{}, invalidArgumentError("Unknown precision model parameter: %s", param)
+		}
+	}
+	return result, nil
+}
@@ -1,7 +1,9 @@
 package geom
 
 import (
+	"errors"
 	assert2 "github.com/stretchr/testify/assert"
+	"math"
 	"testing"
 )
 
@@ -15,7 +17,7 @@ func TestGetMaximumSignificantDigits(t *testing.T) {
 	floating := NewPrecisionModel(FLOATING)
 	floating_single := NewPrecisionModel(FLOATING_SINGLE)
 	fixed := NewPrecisionModel(FIXED)
-	fixedN := NewFixedPrecisionModel(1000)
+	fixedN := mustFixedPrecisionModel(1000)
 	assert.Equal(16, floating.getMaximumSignificantDigits())
 	assert.Equal(6, floating_single.getMaximumSignificantDigits())
 	assert.Equal(1, fixed.getMaximumSignificantDigits())
@@ -23,7 +25,7 @@ func TestGetMaximumSignificantDigits(t *testing.T) {
 }
 
 func TestMakePrecise(t *testing.T) {
-	pm_10 := NewFixedPrecisionModel(0.1)
+	pm_10 := mustFixedPrecisionModel(0.1)
 	precisionCoordinateTester(pm_10, 1200.4, 1240.4, 1200, 1240, t)
 	precisionCoordinateTester(pm_10, 1209.4, 1240.4, 1210, 1240, t)
 }
@@ -34,3 +36,146 @@ func precisionCoordinateTester(pm PrecisionModel, x1, y1, x2, y2 float64, t *tes
 	pPrecise := NewXYCoordinate(x2, y2)
 	assert2.True(t, p.equals2D(&pPrecise))
 }
+
+func TestMakePreciseFloatingSingle(t *testing.T) {
+	pm := NewPrecisionModel(FLOATING_SINGLE)
+	assert2.Equal(t, float64(float32(0.1)), pm.makePrecise(0.1))
+	assert2.NotEqual(t, 0.1, pm.makePrecise(0.1))
+	p := NewXYCoordinate(1.0000001234, 2)
+	pm.makePreciseCoordinate(&p)
+	assert2.Equal(t, float64(float32(1.0000001234)), p.x)
+}
+
+func TestMakePreciseRounding(t *testing.T) {
+	pm := mustFixedPrecisionModel(1)
+	assert2.Equal(t, 3.0, pm.makePrecise(2.5))
+	assert2.Equal(t, -2.0, pm.makePrecise(-2.5))
+	assert2.Equal(t, 0.0, pm.makePrecise(0.49999999999999994))
+	pm.SetRoundingMode(HALF_EVEN)
+	assert2.Equal(t, 2.0, pm.makePrecise(2.5))
+	assert2.Equal(t, 4.0, pm.makePrecise(3.5))
+	assert2.Equal(t, -2.0, pm.makePrecise(-2.5))
+}
+
+func TestGridSize(t *testing.T) {
+	pm := mustPrecisionModelFromGridSize(1000)
+	assert2.Equal(t, 1000.0, pm.getGridSize())
+	assert2.Equal(t, 0.001, pm.getScale())
+	precisionCoordinateTester(pm, 1499.9, 2500, 1000, 3000, t)
+	assert2.False(t, pm.Equals(mustFixedPrecisionModel(1000)))
+	pm2 := mustFixedPrecisionModel(2)
+	assert2.Equal(t, 0.5, pm2.getGridSize())
+	floating := NewDefaultPrecisionModel()
+	assert2.True(t, math.IsNaN(floating.getGridSize()))
+}
+
+func TestOffset(t *testing.T) {
+	pm := mustFixedPrecisionModelWithOffset(0.1, 5, 3)
+	precisionCoordinateTester(pm, 1200.4, 1240.4, 1205, 1243, t)
+	// the offset does not apply to plain values
+	assert2.Equal(t, 1200.0, pm.makePrecise(1200.4))
+}
+
+func TestPrecisionModelEquals(t *testing.T) {
+	fixed := mustFixedPrecisionModel(1000)
+	assert2.True(t, fixed.Equals(mustFixedPrecisionModel(1000)))
+	assert2.False(t, fixed.Equals(mustFixedPrecisionModel(100)))
+	assert2.False(t, fixed.Equals(NewDefaultPrecisionModel()))
+	halfEven := mustFixedPrecisionModel(1000)
+	halfEven.SetRoundingMode(HALF_EVEN)
+	assert2.False(t, fixed.Equals(halfEven))
+	floating := NewDefaultPrecisionModel()
+	assert2.True(t, floating.Equals(NewPrecisionModel(FLOATING)))
+	assert2.True(t, fixed.Equals(PrecisionModel{modelType: FIXED, scale: 1000}))
+}
+
+func TestPrecisionModelString(t *testing.T) {
+	halfEven := mustFixedPrecisionModelWithOffset(1e7, 500000, 0)
+	halfEven.SetRoundingMode(HALF_EVEN)
+	assert2.Equal(t, "Floating", NewDefaultPrecisionModel().String())
+	assert2.Equal(t, "Floating-Single", NewPrecisionModel(FLOATING_SINGLE).String())
+	assert2.Equal(t, "Fixed (Scale=1000)", mustFixedPrecisionModel(1000).String())
+	assert2.Equal(t, "Fixed (GridSize=0.5)", mustPrecisionModelFromGridSize(0.5).String())
+	assert2.Equal(t, "Fixed (Scale=1e+07, OffsetX=500000, OffsetY=0, Rounding=HALF_EVEN)", halfEven.String())
+}
+
+func TestParsePrecisionModel(t *testing.T) {
+	halfEven := mustFixedPrecisionModelWithOffset(1e7, 500000, 0)
+	halfEven.SetRoundingMode(HALF_EVEN)
+	for _, pm := range []PrecisionModel{
+		NewDefaultPrecisionModel(),
+		NewPrecisionModel(FLOATING_SINGLE),
+		NewPrecisionModel(FIXED),
+		mustFixedPrecisionModel(1000),
+		mustFixedPrecisionModel(1.0 / 3),
+		mustPrecisionModelFromGridSize(0.5),
+		halfEven,
+	} {
+		parsed, err := ParsePrecisionModel(pm.String())
+		assert2.Nil(t, err)
+		assert2.True(t, pm.Equals(parsed), pm.String())
+	}
+
+	parsed, err := ParsePrecisionModel(" fixed(scale=100, rounding=half_even) ")
+	assert2.Nil(t, err)
+	assert2.Equal(t, 100.0, parsed.getScale())
+	assert2.Equal(t, HALF_EVEN, parsed.roundingMode)
+	parsed, err = ParsePrecisionModel("FLOATING_SINGLE")
+	assert2.Nil(t, err)
+	assert2.Equal(t, FLOATING_SINGLE, parsed.getType())
+	parsed, err = ParsePrecisionModel("FIXED")
+	assert2.Nil(t, err)
+	assert2.Equal(t, 1.0, parsed.getScale())
+}
+
+func TestParsePrecisionModelInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"Exact",
+		"Floating (Scale=10)",
+		"Fixed (Scale=10",
+		"Fixed (Scale=abc)",
+		"Fixed (Scale=0)",
+		"Fixed (Scale=-10)",
+		"Fixed (Scale=NaN)",
+		"Fixed (GridSize=+Inf)",
+		"Fixed (Scale=10, GridSize=0.1)",
+		"Fixed (Precision=10)",
+		"Fixed (Rounding=DOWN)",
+		"Fixed (Scale)",
+	} {
+		_, err := ParsePrecisionModel(s)
+		assert2.True(t, errors.Is(err, ErrInvalidArgument), s)
+	}
+}
+
+func TestInvalidScale(t *testing.T) {
+	for _, scale := range []float64{0, -1000, math.NaN(), math.Inf(1)} {
+		_, err := NewFixedPrecisionModel(scale)
+		assert2.True(t, errors.Is(err, ErrInvalidArgument), scale)
+		_, err = NewPrecisionModelFromGridSize(scale)
+		assert2.True(t, errors.Is(err, ErrInvalidArgument), scale)
+		_, err = NewFixedPrecisionModelWithOffset(scale, 1, 1)
+		assert2.True(t, errors.Is(err, ErrInvalidArgument), scale)
+	}
+}
+
+func mustFixedPrecisionModel(scale float64) PrecisionModel {
+	return mustFixedPrecisionModelWithOffset(scale, 0, 0)
+}
+
+func mustFixedPrecisionModelWithOffset(scale, offsetX, offsetY float64) PrecisionModel {
+	pm, err := NewFixedPrecisionModelWithOffset(scale, offsetX, offsetY)
+	if err != nil {
+		panic(err)
+	}
+	return pm
+}
+
+func mustPrecisionModelFromGridSize(gridSize float64) PrecisionModel {
+	pm, err := NewPrecisionModelFromGridSize(gridSize)
+	if err != nil {
+		panic(err)
+	}
+	return pm
+}
@@ -0,0 +1,226 @@
+package geom
+
+// A class that represents the edge data structure which implements the quadedge algebra.
+// The quadedge algebra was described in a well-known paper by Guibas and Stolfi,
+// "Primitives for the manipulation of general subdivisions and the computation of Voronoi diagrams",
+// ACM Transactions on Graphics, 4(2), 1985, 75-123.
+//
+// Each edge object is part of a quartet of 4 edges,
+// linked via their rot references.
+// Any edge in the group may be accessed using a series of rot operations.
+// Quadedges in a subdivision are linked together via their next references.
+// The linkage between the quadedge quartets determines the topology
+// of the subdivision.
+//
+// The edge class does not contain separate information for vertices or faces;
+// a vertex is implicitly defined as a ring of edges (created using the next field).
+type QuadEdge struct {
+	rot    *QuadEdge
+	vertex Coordinate
+	next   *QuadEdge
+}
+
+// Creates a new QuadEdge quartet from o to d.
+func makeQuadEdge(o, d Coordinate) *QuadEdge {
+	q0 := &QuadEdge{}
+	q1 := &QuadEdge{}
+	q2 := &QuadEdge{}
+	q3 := &QuadEdge{}
+
+	q0.rot = q1
+	q1.rot = q2
+	q2.rot = q3
+	q3.rot = q0
+
+	q0.next = q0
+	q1.next = q3
+	q2.next = q2
+	q3.next = q1
+
+	base := q0
+	base.setOrig(o)
+	base.setDest(d)
+	return base
+}
+
+// Creates a new QuadEdge connecting the destination of a to the origin of
+// b, in such a way that all three have the same left face after the
+// connection is complete.
+// Additionally, the data pointers of the new edge are set.
+func connectQuadEdges(a, b *QuadEdge) *QuadEdge {
+	e := makeQuadEdge(a.dest(), b.orig())
+	spliceQuadEdges(e, a.lNext())
+	spliceQuadEdges(e.sym(), b)
+	return e
+}
+
+// Splices two edges together or apart.
+// Splice affects the two edge rings around the origins of a and b, and, independently, the two
+// edge rings around the left faces of a and b.
+// In each case, (i) if the two rings are distinct,
+// Splice will combine them into one, or (ii) if the two are the same ring, Splice will break it
+// into two separate pieces. Thus, Splice can be used both to attach the two edges together, and
+// to break them apart.
+func spliceQuadEdges(a, b *QuadEdge) {
+	alpha := a.oNext().rot
+	beta := b.oNext().rot
+
+	t1 := b.oNext()
+	t2 := a.oNext()
+	t3 := beta.oNext()
+	t4 := alpha.oNext()
+
+	a.next = t1
+	b.next = t2
+	alpha.next = t3
+	beta.next = t4
+}
+
+// Turns an edge counterclockwise inside its enclosing quadrilateral.
+func swapQuadEdge(e *QuadEdge) {
+	a := e.oPrev()
+	b := e.sym().oPrev()
+	spliceQuadEdges(e, a)
+	spliceQuadEdges(e.sym(), b)
+	spliceQuadEdges(e, a.lNext())
+	spliceQuadEdges(e.sym(), b.lNext())
+	e.setOrig(a.dest())
+	e.setDest(b.dest())
+}
+
+// Gets the primary edge of this quadedge and its sym.
+// The primary edge is the one for which the origin
+// and destination coordinates are ordered
+// according to the standard Coordinate ordering
+func (q *QuadEdge) getPrimary() *QuadEdge {
+	o := q.orig()
+	d := q.dest()
+	if o.compareTo(d) <= 0 {
+		return q
+	}
+	return q.sym()
+}
+
+// Marks this quadedge as being deleted.
+// This does not free the memory used by
+// this quadedge quartet, but indicates
+// that this edge no longer participates
+// in a subdivision.
+func (q *QuadEdge) delete() {
+	q.rot = nil
+}
+
+// Tests whether this edge has been deleted.
+func (q *QuadEdge) isLive() bool {
+	return q.rot != nil
+}
+
+// Gets the dual of this edge, directed from its right to its left.
+func (q *QuadEdge) rotate() *QuadEdge {
+	return q.rot
+}
+
+// Gets the dual of this edge, directed from its left to its right.
+func (q *QuadEdge) invRot() *QuadEdge {
+	return q.rot.sym()
+}
+
+// Gets the edge from the destination to the origin of this edge.
+func (q *QuadEdge) sym() *QuadEdge {
+	return q.rot.rot
+}
+
+// Gets the next CCW edge around the origin of this edge.
+func (q *QuadEdge) oNext() *QuadEdge {
+	return q.next
+}
+
+// Gets the next CW edge around (from) the origin of this edge.
+func (q *QuadEdge) oPrev() *QuadEdge {
+	return q.rot.next.rot
+}
+
+// Gets the next CCW edge around (into) the destination of this edge.
+func (q *QuadEdge) dNext() *QuadEdge {
+	return q.sym().oNext().sym()
+}
+
+// Gets the next CW edge around (into) the destination of this edge.
+func (q *QuadEdge) dPrev() *QuadEdge {
+	return q.invRot().oNext().invRot()
+}
+
+// Gets the CCW edge around the left face following this edge.
+func (q *QuadEdge) lNext() *QuadEdge {
+	return q.invRot().oNext().rot
+}
+
+// Gets the CCW edge around the left face before this edge.
+func (q *QuadEdge) lPrev() *QuadEdge {
+	return q.next.sym()
+}
+
+// Gets the edge around the right face ccw following this edge.
+func (q *QuadEdge) rNext() *QuadEdge {
+	return q.rot.next.invRot()
+}
+
+// Gets the edge around the right face ccw before this edge.
+func (q *QuadEdge) rPrev() *QuadEdge {
+	return q.sym().oNext()
+}
+
+// Sets the vertex for this edge's origin
+func (q *QuadEdge) setOrig(o Coordinate) {
+	q.vertex = o
+}
+
+// Sets the vertex for this edge's destination
+func (q *QuadEdge) setDest(d Coordinate) {
+	q.sym().setOrig(d)
+}
+
+// Gets the vertex for the edge's origin
+func (q *QuadEdge) orig() Coordinate {
+	return q.vertex
+}
+
+// Gets the vertex for the edge's destination
+func (q *QuadEdge) dest() Coordinate {
+	return q.sym().orig()
+}
+
+// Gets the length of the geometry of this quadedge.
+func (q *QuadEdge) getLength() float64 {
+	o := q.orig()
+	d := q.dest()
+	return o.distance(&d)
+}
+
+// Tests if this quadedge and another have the same line segment geometry,
+// regardless of orientation.
+func (q *QuadEdge) equalsNonOriented(other *QuadEdge) bool {
+	if q.equalsOriented(other) {
+		return true
+	}
+	return q.equalsOriented(other.sym())
+}
+
+// Tests if this quadedge and another have the same line segment geometry
+// with the same orientation.
+func (q *QuadEdge) equalsOriented(other *QuadEdge) bool {
+	o := q.orig()
+	d := q.dest()
+	oo := other.orig()
+	od := other.dest()
+	return o.equals2D(&oo) && d.equals2D(&od)
+}
+
+// Creates a LineSegment representing the geometry of this edge.
+func (q *QuadEdge) toLineSegment() LineSegment {
+	return NewLineSegment(q.orig(), q.dest())
+}
+
+func (q *QuadEdge) String() string {
+	return toLineStringWKT(q.orig(), q.dest())
+}
@@ -0,0 +1,426 @@
+package geom
+
+const (
+	// The size of the frame relative to the extent of the sites
+	frameSizeFactor = 10.0
+	// The ratio of the tolerance to the distance below which
+	// a vertex is considered to lie on an edge
+	edgeCoincidenceTolFactor = 1000.0
+)
+
+// A class that contains the QuadEdge(s) modelling a planar subdivision.
+// The subdivision is constructed using the
+// quadedge algebra defined in the class QuadEdge.
+//
+// All metric calculations are done in the coordinate system of the vertices.
+//
+// In order to avoid loops in the search for a triangle containing a vertex,
+// the subdivision is made to be a Delaunay triangulation by the insertion
+// algorithm; the initial subdivision is a single large triangle, the frame,
+// which contains all the sites to be inserted.
+//
+// The subdivision uses a tolerance value: vertices closer than the tolerance
+// are considered to be equal, using Coordinate.equals2DWithTolerance.
+type QuadEdgeSubdivision struct {
+	quadEdges                []*QuadEdge
+	startingEdge             *QuadEdge
+	tolerance                float64
+	edgeCoincidenceTolerance float64
+	frameVertex              [3]Coordinate
+	frameEnv                 Envelope
+	lastEdge                 *QuadEdge
+}
+
+// Creates a new instance of a quad-edge subdivision based on a frame triangle
+// that encloses a supplied bounding box.
+// A new super-bounding box that contains the triangle is computed and stored.
+func NewQuadEdgeSubdivision(env Envelope, tolerance float64) *QuadEdgeSubdivision {
+	result := &QuadEdgeSubdivision{
+		tolerance:                tolerance,
+		edgeCoincidenceTolerance: tolerance / edgeCoincidenceTolFactor,
+	}
+	result.createFrame(env)
+	result.startingEdge = result.initSubdiv()
+	result.lastEdge = result.startingEdge
+	return result
+}
+
+func (s *QuadEdgeSubdivision) createFrame(env Envelope) {
+	deltaX := env.width()
+	deltaY := env.height()
+	offset := 0.0
+	if deltaX > deltaY {
+		offset = deltaX * frameSizeFactor
+	} else {
+		offset = deltaY * frameSizeFactor
+	}
+	// a degenerate envelope still needs a frame of non-zero size
+	if offset == 0 {
+		offset = frameSizeFactor
+	}
+	s.frameVertex[0] = NewXYCoordinate((env.maxX+env.minX)/2, env.maxY+offset)
+	s.frameVertex[1] = NewXYCoordinate(env.minX-offset, env.minY-offset)
+	s.frameVertex[2] = NewXYCoordinate(env.maxX+offset, env.minY-offset)
+
+	s.frameEnv = NewEnvelopeFromCoordinates(s.frameVertex[0], s.frameVertex[1])
+	s.frameEnv.expandToIncludeCoordinate(s.frameVertex[2])
+}
+
+func (s *QuadEdgeSubdivision) initSubdiv() *QuadEdge {
+	// build initial subdivision from frame
+	ea := s.makeEdge(s.frameVertex[0], s.frameVertex[1])
+	eb := s.makeEdge(s.frameVertex[1], s.frameVertex[2])
+	spliceQuadEdges(ea.sym(), eb)
+	ec := s.makeEdge(s.frameVertex[2], s.frameVertex[0])
+	spliceQuadEdges(eb.sym(), ec)
+	spliceQuadEdges(ec.sym(), ea)
+	return ea
+}
+
+// Gets the vertex-equality tolerance value
+// used in this subdivision
+func (s *QuadEdgeSubdivision) getTolerance() float64 {
+	return s.tolerance
+}
+
+// Gets the envelope of the Subdivision (including the frame).
+func (s *QuadEdgeSubdivision) getEnvelope() Envelope {
+	return s.frameEnv
+}
+
+// Creates a new quadedge, recording it in the edges list.
+func (s *QuadEdgeSubdivision) makeEdge(o, d Coordinate) *QuadEdge {
+	q := makeQuadEdge(o, d)
+	s.quadEdges = append(s.quadEdges, q)
+	return q
+}
+
+// Creates a new QuadEdge connecting the destination of a to the origin of b,
+// in such a way that all three have the same left face after the connection
+// is complete. The quadedge is recorded in the edges list.
+func (s *QuadEdgeSubdivision) connect(a, b *QuadEdge) *QuadEdge {
+	q := connectQuadEdges(a, b)
+	s.quadEdges = append(s.quadEdges, q)
+	return q
+}
+
+// Deletes a quadedge from the subdivision. Linked quadedges are updated to
+// reflect the deletion.
+func (s *QuadEdgeSubdivision) delete(e *QuadEdge) {
+	spliceQuadEdges(e, e.oPrev())
+	spliceQuadEdges(e.sym(), e.sym().oPrev())
+
+	eSym := e.sym()
+	eRot := e.rotate()
+	eRotSym := e.rotate().sym()
+
+	// this is inefficient on an ArrayList, but this method should be called infrequently
+	for i, q := range s.quadEdges {
+		if q == e || q == eSym || q == eRot || q == eRotSym {
+			s.quadEdges = append(s.quadEdges[:i], s.quadEdges[i+1:]...)
+			break
+		}
+	}
+
+	e.delete()
+	eSym.delete()
+	eRot.delete()
+	eRotSym.delete()
+	if s.lastEdge == e || s.lastEdge == eSym {
+		s.lastEdge = s.startingEdge
+	}
+}
+
+// Locates an edge of a triangle which contains a location
+// specified by a vertex v.
+// The edge returned has the
+// property that either v is on e, or e is an edge of a triangle containing v.
+// The search starts from startEdge and proceeds on the general direction of v.
+//
+// This locate algorithm relies on the subdivision being Delaunay. For
+// non-Delaunay subdivisions, this may loop for ever, so the number of
+// steps is bounded and an error is returned when it is exceeded.
+func (s *QuadEdgeSubdivision) locateFromEdge(v Coordinate, startEdge *QuadEdge) (*QuadEdge, error) {
+	iter := 0
+	maxIter := len(s.quadEdges)
+
+	e := startEdge
+	for {
+		iter++
+		// So far it has always been the case that failure to locate indicates an
+		// invalid subdivision. So just fail completely.
+		if iter > maxIter {
+			return nil, NewTopologyError("locate", v, "failed to converge").
+				addInputLines([]Coordinate{e.orig(), e.dest()})
+		}
+		orig := e.orig()
+		dest := e.dest()
+		if v.equals2D(&orig) || v.equals2D(&dest) {
+			break
+		} else if isRightOf(v, e) {
+			e = e.sym()
+		} else if !isRightOf(v, e.oNext()) {
+			e = e.oNext()
+		} else if !isRightOf(v, e.dPrev()) {
+			e = e.dPrev()
+		} else {
+			// on edge or in triangle containing edge
+			break
+		}
+	}
+	return e, nil
+}
+
+// Finds a quadedge of a triangle containing a location
+// specified by a Coordinate, if one exists.
+// The search starts from the edge found by the previous location,
+// which makes locating points close to each other efficient.
+func (s *QuadEdgeSubdivision) locate(p Coordinate) (*QuadEdge, error) {
+	if s.lastEdge == nil || !s.lastEdge.isLive() {
+		s.lastEdge = s.startingEdge
+	}
+	e, err := s.locateFromEdge(p, s.lastEdge)
+	if err != nil {
+		return nil, err
+	}
+	s.lastEdge = e
+	return e, nil
+}
+
+// Tests whether a Coordinate lies on a QuadEdge, up to a
+// tolerance determined by the subdivision tolerance.
+func (s *QuadEdgeSubdivision) isOnEdge(e *QuadEdge, p Coordinate) bool {
+	seg := e.toLineSegment()
+	return seg.distance(p) < s.edgeCoincidenceTolerance
+}
+
+// Tests whether a vertex is the start or end vertex of a
+// QuadEdge, up to the subdivision tolerance distance.
+func (s *QuadEdgeSubdivision) isVertexOfEdge(e *QuadEdge, v Coordinate) bool {
+	orig := e.orig()
+	dest := e.dest()
+	return v.equals2DWithTolerance(&orig, s.tolerance) || v.equals2DWithTolerance(&dest, s.tolerance)
+}
+
+// Tests whether a QuadEdge is an edge incident on a frame triangle vertex.
+func (s *QuadEdgeSubdivision) isFrameEdge(e *QuadEdge) bool {
+	return s.isFrameVertex(e.orig()) || s.isFrameVertex(e.dest())
+}
+
+// Tests whether a QuadEdge is an edge on the border of the frame facets and
+// the internal facets. E.g. an edge which does not itself touch a frame
+// vertex, but which touches an edge which does.
+func (s *QuadEdgeSubdivision) isFrameBorderEdge(e *QuadEdge) bool {
+	// check other vertex of triangle to left of edge
+	leftTri := [3]*QuadEdge{e, e.lNext(), e.lNext().lNext()}
+	vLeftTriOther := leftTri[1].dest()
+	if s.isFrameVertex(vLeftTriOther) {
+		return true
+	}
+	// check other vertex of triangle to right of edge
+	rightTri := [3]*QuadEdge{e.sym(), e.sym().lNext(), e.sym().lNext().lNext()}
+	vRightTriOther := rightTri[1].dest()
+	return s.isFrameVertex(vRightTriOther)
+}
+
+// Tests whether a vertex is a vertex of the outer triangle.
+func (s *QuadEdgeSubdivision) isFrameVertex(v Coordinate) bool {
+	for i := range s.frameVertex {
+		if v.equals2D(&s.frameVertex[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Finds the quadedge which has the given vertex as its origin and
+// ends at the other given vertex, if it exists in the subdivision.
+func (s *QuadEdgeSubdivision) findEdge(p0, p1 Coordinate) *QuadEdge {
+	e, err := s.locate(p0)
+	if err != nil {
+		return nil
+	}
+	// the located edge may have p0 as its origin, its destination,
+	// or as the opposite vertex of the triangle to its left
+	found := false
+	for _, cand := range []*QuadEdge{e, e.sym(), e.lPrev()} {
+		orig := cand.orig()
+		if p0.equals2D(&orig) {
+			e = cand
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+	start := e
+	for {
+		dest := e.dest()
+		if p1.equals2D(&dest) {
+			return e
+		}
+		e = e.oNext()
+		if e == start {
+			return nil
+		}
+	}
+}
+
+// Gets the unique primary quadedges of the subdivision.
+// If includeFrame is false, the edges incident on the frame vertices
+// are omitted.
+func (s *QuadEdgeSubdivision) getPrimaryEdges(includeFrame bool) []*QuadEdge {
+	var edges []*QuadEdge
+	for _, q := range s.quadEdges {
+		if includeFrame || !s.isFrameEdge(q) {
+			edges = append(edges, q.getPrimary())
+		}
+	}
+	return edges
+}
+
+// Gets the coordinates of the edges of the subdivision
+// which are not incident on the frame, each as an array of 2 Coordinate(s).
+func (s *QuadEdgeSubdivision) getEdgeCoordinates() [][]Coordinate {
+	var edges [][]Coordinate
+	for _, q := range s.getPrimaryEdges(false) {
+		edges = append(edges, []Coordinate{q.orig(), q.dest()})
+	}
+	return edges
+}
+
+// Gets the unique vertices of the subdivision, in the order in which
+// they are first reached by the primary edges.
+// If includeFrame is false, the frame vertices are omitted.
+func (s *QuadEdgeSubdivision) getVertices(includeFrame bool) []Coordinate {
+	var vertices []Coordinate
+	seen := make(map[CoordinateKey]bool)
+	for _, q := range s.quadEdges {
+		for _, v := range []Coordinate{q.orig(), q.dest()} {
+			key := CoordinateKey{v.x, v.y}
+			if seen[key] || (!includeFrame && s.isFrameVertex(v)) {
+				continue
+			}
+			seen[key] = true
+			vertices = append(vertices, v)
+		}
+	}
+	return vertices
+}
+
+// Gets a collection of QuadEdge(s) whose origin
+// vertices are a unique set which includes
+// all vertices in the subdivision.
+// The frame vertices can be included if required.
+func (s *QuadEdgeSubdivision) getVertexUniqueEdges(includeFrame bool) []*QuadEdge {
+	var edges []*QuadEdge
+	seen := make(map[CoordinateKey]bool)
+	for _, q := range s.quadEdges {
+		for _, e := range []*QuadEdge{q, q.sym()} {
+			v := e.orig()
+			key := CoordinateKey{v.x, v.y}
+			if seen[key] || (!includeFrame && s.isFrameVertex(v)) {
+				continue
+			}
+			seen[key] = true
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// Gets the edges of each triangular face of the subdivision,
+// each as an array of 3 QuadEdge(s) which traverse the face
+// in counter-clockwise order.
+// If includeFrame is false, the triangles touching the frame vertices
+// are omitted.
+func (s *QuadEdgeSubdivision) getTriangleEdges(includeFrame bool) [][3]*QuadEdge {
+	var triangles [][3]*QuadEdge
+	visited := make(map[*QuadEdge]bool)
+	edgeStack := []*QuadEdge{s.startingEdge}
+	for len(edgeStack) > 0 {
+		edge := edgeStack[len(edgeStack)-1]
+		edgeStack = edgeStack[:len(edgeStack)-1]
+		if visited[edge] {
+			continue
+		}
+		tri, ok := s.fetchTriangleToVisit(edge, &edgeStack, includeFrame, visited)
+		if ok {
+			triangles = append(triangles, tri)
+		}
+	}
+	return triangles
+}
+
+// Stores the edges for a visited triangle. Also pushes sym (neighbour) edges
+// on stack to visit later.
+// Returns false if the face is not a triangle or is a frame triangle
+// which is not to be included.
+func (s *QuadEdgeSubdivision) fetchTriangleToVisit(edge *QuadEdge, edgeStack *[]*QuadEdge,
+	includeFrame bool, visited map[*QuadEdge]bool) ([3]*QuadEdge, bool) {
+	var triEdges [3]*QuadEdge
+	curr := edge
+	edgeCount := 0
+	isFrame := false
+	for {
+		if edgeCount < 3 {
+			triEdges[edgeCount] = curr
+		}
+		if s.isFrameEdge(curr) {
+			isFrame = true
+		}
+		// push sym edges to visit next
+		sym := curr.sym()
+		if !visited[sym] {
+			*edgeStack = append(*edgeStack, sym)
+		}
+		// mark this edge as visited
+		visited[curr] = true
+		edgeCount++
+		curr = curr.lNext()
+		if curr == edge {
+			break
+		}
+	}
+	if edgeCount != 3 || (isFrame && !includeFrame) {
+		return triEdges, false
+	}
+	return triEdges, true
+}
+
+// Gets the coordinates of the triangles of the subdivision,
+// each as a closed ring of 4 Coordinate(s) in counter-clockwise order.
+func (s *QuadEdgeSubdivision) getTriangleCoordinates(includeFrame bool) [][]Coordinate {
+	var triangles [][]Coordinate
+	for _, tri := range s.getTriangleEdges(includeFrame) {
+		triangles = append(triangles, []Coordinate{
+			tri[0].orig(), tri[1].orig(), tri[2].orig(), tri[0].orig(),
+		})
+	}
+	return triangles
+}
+
+// Gets the Voronoi cell around the origin of a quadedge,
+// as a closed ring of the circumcentres of the triangles around the vertex.
+func (s *QuadEdgeSubdivision) getVoronoiCell(qe *QuadEdge) []Coordinate {
+	var cellPts []Coordinate
+	startQE := qe
+	for {
+		// the triangle to the left of the edge
+		cc := circumcentre(qe.orig(), qe.dest(), qe.lNext().dest())
+		cellPts = append(cellPts, cc)
+		qe = qe.oPrev()
+		if qe == startQE {
+			break
+		}
+	}
+	cellPts = removeRepeatedPoints(cellPts)
+	// close the ring
+	return append(cellPts, cellPts[0])
+}
+
+// Tests whether a point lies to the right of a quadedge.
+func isRightOf(p Coordinate, e *QuadEdge) bool {
+	return orientationIndex(e.dest(), e.orig(), p) > 0
+}
@@ -0,0 +1,90 @@
+package geom
+
+import "math"
+
+// Creates geometries which are shaped like multi-armed stars
+// with each arm shaped like a sine wave.
+// These kinds of geometries are useful as a more complex
+// geometry for testing algorithms.
+type SineStarFactory struct {
+	GeometricShapeFactory
+	numArms        int
+	armLengthRatio float64
+}
+
+// Creates a factory which will create sine stars using
+// a floating PrecisionModel, with 8 arms of length ratio 0.5.
+func NewSineStarFactory() SineStarFactory {
+	return NewSineStarFactoryFromPrecisionModel(NewDefaultPrecisionModel())
+}
+
+// Creates a factory which will create sine stars
+// whose coordinates are rounded to the given PrecisionModel.
+func NewSineStarFactoryFromPrecisionModel(pm PrecisionModel) SineStarFactory {
+	return SineStarFactory{
+		GeometricShapeFactory: NewGeometricShapeFactoryFromPrecisionModel(pm),
+		numArms:               8,
+		armLengthRatio:        0.5,
+	}
+}
+
+// Creates a sine star with the given parameters.
+// Returns ErrInvalidArgument if fewer than 3 points are requested.
+func CreateSineStar(origin Coordinate, size float64, nPts, nArms int, armLengthRatio float64) ([]Coordinate, error) {
+	gsf := NewSineStarFactory()
+	gsf.SetCentre(origin)
+	gsf.SetSize(size)
+	if err := gsf.SetNumPoints(nPts); err != nil {
+		return nil, err
+	}
+	gsf.SetArmLengthRatio(armLengthRatio)
+	gsf.SetNumArms(nArms)
+	return gsf.CreateSineStar(), nil
+}
+
+// Sets the number of arms in the star
+func (f *SineStarFactory) SetNumArms(numArms int) {
+	f.numArms = numArms
+}
+
+// Sets the ratio of the length of each arm to the radius of the star.
+// A smaller number makes the arms shorter.
+// Value should be between 0.0 and 1.0
+func (f *SineStarFactory) SetArmLengthRatio(armLengthRatio float64) {
+	f.armLengthRatio = armLengthRatio
+}
+
+// Generates the polygon for the sine star.
+func (f *SineStarFactory) CreateSineStar() []Coordinate {
+	env := f.dim.getEnvelope()
+	radius := env.width() / 2
+
+	armRatio := math.Max(0, math.Min(1, f.armLengthRatio))
+	armMaxLen := armRatio * radius
+	insideRadius := (1 - armRatio) * radius
+
+	centreX := env.minX + radius
+	centreY := env.minY + radius
+
+	pts := make([]Coordinate, 0, f.nPts+1)
+	for i := 0; i < f.nPts; i++ {
+		// the fraction of the way through the current arm - in [0,1]
+		ptArcFrac := (float64(i) / float64(f.nPts)) * float64(f.numArms)
+		armAngFrac := ptArcFrac - math.Floor(ptArcFrac)
+
+		// the angle for the current arm - in [0,2Pi]
+		// (each arm is a complete sine wave cycle)
+		armAng := 2 * math.Pi * armAngFrac
+		// the current length of the arm
+		armLenFrac := (math.Cos(armAng) + 1) / 2
+
+		// the current radius of the curve (core + arm)
+		curveRadius := insideRadius + armMaxLen*armLenFrac
+
+		// the current angle of the curve
+		ang := float64(i) * (2 * math.Pi / float64(f.nPts))
+		pts = append(pts, NewXYCoordinate(curveRadius*math.Cos(ang)+centreX, curveRadius*math.Sin(ang)+centreY))
+	}
+	pts = append(pts, pts[0])
+	return f.finish(pts)
+}
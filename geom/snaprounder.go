@@ -0,0 +1,193 @@
+package geom
+
+import "sort"
+
+// Implements a hot pixel of a snap-rounding noder:
+// a square of the precision grid, centred on a rounded point,
+// which captures every segment passing through it.
+type hotPixel struct {
+	pt       Coordinate
+	halfSize float64
+}
+
+// Gets the envelope of the closed square of this hot pixel.
+func (hp *hotPixel) envelope() Envelope {
+	return NewEnvelope(hp.pt.x-hp.halfSize, hp.pt.x+hp.halfSize, hp.pt.y-hp.halfSize, hp.pt.y+hp.halfSize)
+}
+
+// Tests whether a segment intersects the closed square of this hot pixel,
+// using Liang-Barsky clipping.
+func (hp *hotPixel) intersectsSegment(p0, p1 Coordinate) bool {
+	minX := hp.pt.x - hp.halfSize
+	maxX := hp.pt.x + hp.halfSize
+	minY := hp.pt.y - hp.halfSize
+	maxY := hp.pt.y + hp.halfSize
+	dx := p1.x - p0.x
+	dy := p1.y - p0.y
+	t0 := 0.0
+	t1 := 1.0
+	for _, pq := range [4][2]float64{
+		{-dx, p0.x - minX},
+		{dx, maxX - p0.x},
+		{-dy, p0.y - minY},
+		{dy, maxY - p0.y},
+	} {
+		p, q := pq[0], pq[1]
+		if p == 0 {
+			if q < 0 {
+				return false
+			}
+			continue
+		}
+		r := q / p
+		if p < 0 {
+			if r > t1 {
+				return false
+			}
+			if r > t0 {
+				t0 = r
+			}
+		} else {
+			if r < t0 {
+				return false
+			}
+			if r < t1 {
+				t1 = r
+			}
+		}
+	}
+	return true
+}
+
+// Uses Snap Rounding to compute a rounded,
+// fully noded arrangement from a set of lines.
+// Hot pixels are created at the rounded vertices and at the rounded
+// intersection points of the input segments.
+// Every segment is then replaced by the chain of hot pixel centres it
+// passes through, which guarantees that the output lines
+// only intersect at their vertices.
+//
+// The segments and the hot pixels are kept in grid indexes,
+// so that each segment is only tested against those nearby.
+//
+// The precision model must be FIXED.
+type snapRounder struct {
+	pm         PrecisionModel
+	pixels     []hotPixel
+	pixelKeys  map[CoordinateKey]bool
+	pixelIndex gridIndex
+}
+
+func newSnapRounder(pm PrecisionModel) snapRounder {
+	return snapRounder{
+		pm:        pm,
+		pixelKeys: make(map[CoordinateKey]bool),
+	}
+}
+
+// Computes the snap-rounded versions of the lines.
+// Consecutive repeated points are removed, so a line may collapse to
+// a single point.
+func (r *snapRounder) snapRound(lines [][]Coordinate) [][]Coordinate {
+	for _, pts := range lines {
+		for _, p := range pts {
+			r.addHotPixel(p)
+		}
+	}
+	r.addIntersectionPixels(lines)
+
+	env := NewEmptyEnvelope()
+	for i := range r.pixels {
+		env.expandToIncludeCoordinate(r.pixels[i].pt)
+	}
+	r.pixelIndex = newGridIndex(env, len(r.pixels))
+	for i := range r.pixels {
+		r.pixelIndex.insert(r.pixels[i].envelope())
+	}
+
+	result := make([][]Coordinate, len(lines))
+	for i, pts := range lines {
+		result[i] = r.snapLine(pts)
+	}
+	return result
+}
+
+func (r *snapRounder) round(p Coordinate) Coordinate {
+	rounded := p
+	r.pm.makePreciseCoordinate(&rounded)
+	return rounded
+}
+
+func (r *snapRounder) addHotPixel(p Coordinate) {
+	rounded := r.round(p)
+	key := CoordinateKey{rounded.x, rounded.y}
+	if r.pixelKeys[key] {
+		return
+	}
+	r.pixelKeys[key] = true
+	r.pixels = append(r.pixels, hotPixel{
+		pt:       rounded,
+		halfSize: 0.5 / r.pm.scale,
+	})
+}
+
+// Adds hot pixels for the intersections of all pairs of segments.
+// Only the pairs of segments with intersecting envelopes are tested.
+func (r *snapRounder) addIntersectionPixels(lines [][]Coordinate) {
+	var segs []LineSegment
+	env := NewEmptyEnvelope()
+	for _, pts := range lines {
+		for i := 1; i < len(pts); i++ {
+			segs = append(segs, NewLineSegment(pts[i-1], pts[i]))
+			env.expandToIncludeCoordinate(pts[i-1])
+			env.expandToIncludeCoordinate(pts[i])
+		}
+	}
+	index := newGridIndex(env, len(segs))
+	for i := range segs {
+		index.insert(NewEnvelopeFromCoordinates(segs[i].p0, segs[i].p1))
+	}
+	for i := range segs {
+		index.query(NewEnvelopeFromCoordinates(segs[i].p0, segs[i].p1), func(j int) {
+			if j <= i {
+				return
+			}
+			if pt, ok := segs[i].intersection(segs[j]); ok {
+				r.addHotPixel(pt)
+			}
+		})
+	}
+}
+
+// Replaces each segment of a line by the centres of
+// the hot pixels it passes through, in order along the segment.
+func (r *snapRounder) snapLine(pts []Coordinate) []Coordinate {
+	if len(pts) == 0 {
+		return []Coordinate{}
+	}
+	result := []Coordinate{r.round(pts[0])}
+	for i := 1; i < len(pts); i++ {
+		seg := NewLineSegment(pts[i-1], pts[i])
+		if seg.p0.equals2D(&seg.p1) {
+			continue
+		}
+		start := r.round(seg.p0)
+		end := r.round(seg.p1)
+		var nodes []Coordinate
+		r.pixelIndex.query(NewEnvelopeFromCoordinates(seg.p0, seg.p1), func(j int) {
+			hp := &r.pixels[j]
+			if hp.pt.equals2D(&start) || hp.pt.equals2D(&end) {
+				return
+			}
+			if hp.intersectsSegment(seg.p0, seg.p1) {
+				nodes = append(nodes, hp.pt)
+			}
+		})
+		sort.SliceStable(nodes, func(a, b int) bool {
+			return seg.projectionFactor(nodes[a]) < seg.projectionFactor(nodes[b])
+		})
+		result = append(result, nodes...)
+		result = append(result, end)
+	}
+	return removeRepeatedPoints(result)
+}
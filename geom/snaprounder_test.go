@@ -0,0 +1,50 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestSnapRoundCrossingLines(t *testing.T) {
+	rounder := newSnapRounder(mustFixedPrecisionModel(1))
+	lines := rounder.snapRound([][]Coordinate{
+		createLine(0, 0, 10, 10.2),
+		createLine(0, 10, 10, 0),
+		createLine(20, 20, 30, 20),
+	})
+	checkLine(createLine(0, 0, 5, 5, 10, 10), lines[0], t)
+	checkLine(createLine(0, 10, 5, 5, 10, 0), lines[1], t)
+	checkLine(createLine(20, 20, 30, 20), lines[2], t)
+}
+
+func TestSnapRoundLineThroughVertex(t *testing.T) {
+	rounder := newSnapRounder(mustFixedPrecisionModel(1))
+	lines := rounder.snapRound([][]Coordinate{
+		createLine(0, 0, 10, 0.4),
+		createLine(4.2, 0.3, 4.4, 5),
+	})
+	assert2.Equal(t, 2, len(lines))
+	checkLine(createLine(0, 0, 4, 0, 10, 0), lines[0], t)
+}
+
+func BenchmarkSnapRound(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	lines := make([][]Coordinate, 200)
+	for i := range lines {
+		line := make([]Coordinate, 20)
+		x, y := random.Float64()*1000, random.Float64()*1000
+		for j := range line {
+			x += random.Float64()*20 - 10
+			y += random.Float64()*20 - 10
+			line[j] = NewXYCoordinate(x, y)
+		}
+		lines[i] = line
+	}
+	pm := mustFixedPrecisionModel(10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rounder := newSnapRounder(pm)
+		rounder.snapRound(lines)
+	}
+}
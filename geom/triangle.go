@@ -0,0 +1,67 @@
+package geom
+
+// Computes the orientation index of the point q relative to
+// the directed segment p1-p2.
+// Returns 1 if q is to the left of the segment (counter-clockwise),
+// -1 if it is to the right (clockwise) and 0 if the points are collinear.
+func orientationIndex(p1, p2, q Coordinate) int {
+	dx1 := p2.x - p1.x
+	dy1 := p2.y - p1.y
+	dx2 := q.x - p2.x
+	dy2 := q.y - p2.y
+	det := dx1*dy2 - dy1*dx2
+	if det > 0 {
+		return 1
+	}
+	if det < 0 {
+		return -1
+	}
+	return 0
+}
+
+// Tests if a point lies inside the circle defined by the points a, b, c,
+// which are assumed to be in counter-clockwise order.
+// The points are translated so that p is the origin before the
+// determinant is computed, to reduce round-off.
+func isInCircle(a, b, c, p Coordinate) bool {
+	adx := a.x - p.x
+	ady := a.y - p.y
+	bdx := b.x - p.x
+	bdy := b.y - p.y
+	cdx := c.x - p.x
+	cdy := c.y - p.y
+
+	abdet := adx*bdy - bdx*ady
+	bcdet := bdx*cdy - cdx*bdy
+	cadet := cdx*ady - adx*cdy
+	alift := adx*adx + ady*ady
+	blift := bdx*bdx + bdy*bdy
+	clift := cdx*cdx + cdy*cdy
+
+	disc := alift*bcdet + blift*cadet + clift*abdet
+	return disc > 0
+}
+
+// Computes the circumcentre of a triangle.
+// The circumcentre is the centre of the circumcircle,
+// the smallest circle which encloses the triangle.
+// It is also the common intersection point of the
+// perpendicular bisectors of the sides of the triangle,
+// and is the only point which has equal distance to all three
+// vertices of the triangle.
+// The circumcentre does not necessarily lie within the triangle;
+// for collinear points it is not finite.
+func circumcentre(a, b, c Coordinate) Coordinate {
+	cx := c.x
+	cy := c.y
+	ax := a.x - cx
+	ay := a.y - cy
+	bx := b.x - cx
+	by := b.y - cy
+
+	denom := 2 * (ax*by - ay*bx)
+	numx := ay*(bx*bx+by*by) - by*(ax*ax+ay*ay)
+	numy := ax*(bx*bx+by*by) - bx*(ax*ax+ay*ay)
+
+	return NewXYCoordinate(cx-numx/denom, cy+numy/denom)
+}
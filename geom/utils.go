@@ -22,3 +22,13 @@ func clamp(x, min, max int) int {
 	}
 	return x
 }
+
+func compareInts(a, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,168 @@
+package geom
+
+import "math"
+
+// A cell of a Voronoi diagram: the region of the plane which is
+// closer to its site than to any other site of the diagram.
+type VoronoiCell struct {
+	site  Coordinate
+	shell []Coordinate
+}
+
+// Gets the site which generated this cell.
+func (c *VoronoiCell) GetSite() Coordinate {
+	return c.site
+}
+
+// Gets the boundary of this cell, as a closed ring of Coordinate(s).
+func (c *VoronoiCell) GetShell() []Coordinate {
+	return c.shell
+}
+
+// A utility class which creates Voronoi Diagrams
+// from collections of points.
+// The diagram is returned as a list of VoronoiCell(s),
+// each carrying the site which generated it.
+//
+// The cells are clipped to an envelope, which by default
+// is the envelope of the sites expanded by the larger of its width and height.
+// A larger clip envelope can be supplied with SetClipEnvelope.
+type VoronoiDiagramBuilder struct {
+	siteCoords []Coordinate
+	tolerance  float64
+	subdiv     *QuadEdgeSubdivision
+	clipEnv    *Envelope
+	diagramEnv Envelope
+}
+
+// Creates a new Voronoi diagram builder.
+func NewVoronoiDiagramBuilder() VoronoiDiagramBuilder {
+	return VoronoiDiagramBuilder{}
+}
+
+// Sets the sites (point or vertices) which will be diagrammed.
+func (b *VoronoiDiagramBuilder) SetSites(coords []Coordinate) {
+	b.siteCoords = copyDeepCoordinateArray(coords)
+	b.subdiv = nil
+}
+
+// Sets the envelope to clip the diagram to.
+// The diagram will be clipped to the larger
+// of this envelope or an envelope surrounding the sites.
+func (b *VoronoiDiagramBuilder) SetClipEnvelope(clipEnv Envelope) {
+	env := CopyEnvelope(clipEnv)
+	b.clipEnv = &env
+	b.subdiv = nil
+}
+
+// Sets the snapping tolerance which will be used
+// to improved the robustness of the triangulation computation.
+// A tolerance of 0.0 specifies that no snapping will take place.
+func (b *VoronoiDiagramBuilder) SetTolerance(tolerance float64) {
+	b.tolerance = tolerance
+	b.subdiv = nil
+}
+
+func (b *VoronoiDiagramBuilder) create() error {
+	if b.subdiv != nil {
+		return nil
+	}
+	sites := uniqueCoordinates(b.siteCoords, b.tolerance)
+	b.diagramEnv = envelope(sites)
+	// add a buffer around the final envelope
+	expandBy := math.Max(b.diagramEnv.width(), b.diagramEnv.height())
+	b.diagramEnv.expandBy(expandBy, expandBy)
+	if b.clipEnv != nil {
+		b.diagramEnv.expandToIncludeEnvelope(*b.clipEnv)
+	}
+
+	// the frame encloses the diagram envelope, so that the
+	// cells of the hull sites are not cut short by the frame vertices
+	subdiv := NewQuadEdgeSubdivision(b.diagramEnv, b.tolerance)
+	triangulator := newIncrementalDelaunayTriangulator(subdiv)
+	if err := triangulator.insertSites(sites); err != nil {
+		return err
+	}
+	b.subdiv = subdiv
+	return nil
+}
+
+// Gets the QuadEdgeSubdivision which models the computed diagram.
+func (b *VoronoiDiagramBuilder) GetSubdivision() (*QuadEdgeSubdivision, error) {
+	if err := b.create(); err != nil {
+		return nil, err
+	}
+	return b.subdiv, nil
+}
+
+// Gets the cells of the computed diagram, one for each unique site,
+// clipped to the diagram envelope.
+func (b *VoronoiDiagramBuilder) GetDiagram() ([]VoronoiCell, error) {
+	if err := b.create(); err != nil {
+		return nil, err
+	}
+	var cells []VoronoiCell
+	for _, qe := range b.subdiv.getVertexUniqueEdges(false) {
+		shell := clipRingToEnvelope(b.subdiv.getVoronoiCell(qe), b.diagramEnv)
+		if len(shell) < 4 {
+			continue
+		}
+		cells = append(cells, VoronoiCell{
+			site:  qe.orig(),
+			shell: shell,
+		})
+	}
+	return cells, nil
+}
+
+// Clips a closed ring to an Envelope, using the Sutherland-Hodgman algorithm.
+// The result is exact for convex rings, such as Voronoi cells.
+// Returns a closed ring, or an empty array if the ring lies outside the envelope.
+func clipRingToEnvelope(ring []Coordinate, env Envelope) []Coordinate {
+	if env.isNull() || len(ring) == 0 {
+		return []Coordinate{}
+	}
+	pts := ring
+	if isRing(pts) {
+		pts = pts[:len(pts)-1]
+	}
+	// each edge of the envelope, as an inside test and an intersection along the edge
+	pts = clipToHalfPlane(pts, func(c Coordinate) float64 { return c.x - env.minX })
+	pts = clipToHalfPlane(pts, func(c Coordinate) float64 { return env.maxX - c.x })
+	pts = clipToHalfPlane(pts, func(c Coordinate) float64 { return c.y - env.minY })
+	pts = clipToHalfPlane(pts, func(c Coordinate) float64 { return env.maxY - c.y })
+	pts = removeRepeatedPoints(pts)
+	if len(pts) > 1 && pts[0].equals2D(&pts[len(pts)-1]) {
+		pts = pts[:len(pts)-1]
+	}
+	if len(pts) < 3 {
+		return []Coordinate{}
+	}
+	return append(pts, pts[0])
+}
+
+// Clips an open ring to the half-plane where the signed distance
+// function is non-negative.
+func clipToHalfPlane(pts []Coordinate, dist func(Coordinate) float64) []Coordinate {
+	if len(pts) == 0 {
+		return pts
+	}
+	result := make([]Coordinate, 0, len(pts)+1)
+	prev := pts[len(pts)-1]
+	prevDist := dist(prev)
+	for _, curr := range pts {
+		currDist := dist(curr)
+		if (currDist >= 0) != (prevDist >= 0) {
+			frac := prevDist / (prevDist - currDist)
+			result = append(result, NewXYCoordinate(
+				prev.x+frac*(curr.x-prev.x),
+				prev.y+frac*(curr.y-prev.y)))
+		}
+		if currDist >= 0 {
+			result = append(result, curr)
+		}
+		prev = curr
+		prevDist = currDist
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package geom
+
+import (
+	assert2 "github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestVoronoiSquareWithCentre(t *testing.T) {
+	builder := NewVoronoiDiagramBuilder()
+	builder.SetSites(createLine(0, 0, 10, 0, 0, 10, 10, 10, 5, 5))
+	cells, err := builder.GetDiagram()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 5, len(cells))
+	for _, cell := range cells {
+		site := cell.GetSite()
+		if site.equals2D(&Coordinate{x: 5, y: 5}) {
+			assert2.Equal(t, 5, len(cell.GetShell()))
+			assert2.InDelta(t, 50.0, math.Abs(signedRingArea(cell.GetShell())), 1e-9)
+		}
+	}
+}
+
+func TestVoronoiClipEnvelope(t *testing.T) {
+	builder := NewVoronoiDiagramBuilder()
+	builder.SetSites(createLine(0, 0, 10, 0, 5, 8))
+	clipEnv := NewEnvelope(-100, 100, -100, 100)
+	builder.SetClipEnvelope(clipEnv)
+	cells, err := builder.GetDiagram()
+	assert2.Nil(t, err)
+	assert2.Equal(t, 3, len(cells))
+	total := 0.0
+	for _, cell := range cells {
+		shell := cell.GetShell()
+		assert2.True(t, isRing(shell))
+		for _, p := range shell {
+			assert2.True(t, clipEnv.coversCoordinate(p))
+		}
+		total += math.Abs(signedRingArea(shell))
+	}
+	// the cells tile the clip envelope
+	assert2.InDelta(t, clipEnv.area(), total, 1e-6)
+}
+
+func TestClipRingToEnvelope(t *testing.T) {
+	ring := createLine(-5, -5, 5, -5, 5, 5, -5, 5, -5, -5)
+	clipped := clipRingToEnvelope(ring, NewEnvelope(0, 10, 0, 10))
+	assert2.True(t, isRing(clipped))
+	assert2.InDelta(t, 25.0, math.Abs(signedRingArea(clipped)), 1e-9)
+	assert2.Equal(t, 0, len(clipRingToEnvelope(ring, NewEnvelope(20, 30, 20, 30))))
+}
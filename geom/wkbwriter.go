@@ -0,0 +1,42 @@
+package geom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+)
+
+// The ISO WKB geometry type codes.
+const (
+	wkbLineString     uint32 = 2
+	wkbCircularString uint32 = 8
+	wkbCompoundCurve  uint32 = 9
+	wkbCurvePolygon   uint32 = 10
+)
+
+// Writes the header of a WKB geometry, in little-endian (NDR) byte order.
+func writeWKBHeader(buf *bytes.Buffer, geometryType uint32) {
+	buf.WriteByte(1)
+	writeWKBUint32(buf, geometryType)
+}
+
+func writeWKBUint32(buf *bytes.Buffer, value uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], value)
+	buf.Write(b[:])
+}
+
+func writeWKBFloat64(buf *bytes.Buffer, value float64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(value))
+	buf.Write(b[:])
+}
+
+// Writes a count followed by the X and Y ordinates of the Coordinate(s).
+func writeWKBPoints(buf *bytes.Buffer, pts []Coordinate) {
+	writeWKBUint32(buf, uint32(len(pts)))
+	for _, p := range pts {
+		writeWKBFloat64(buf, p.x)
+		writeWKBFloat64(buf, p.y)
+	}
+}
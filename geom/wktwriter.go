@@ -0,0 +1,40 @@
+package geom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Generates the WKT for a POINT specified by the given Coordinate.
+func toPointWKT(p Coordinate) string {
+	return fmt.Sprintf("POINT ( %g %g )", p.x, p.y)
+}
+
+// Generates the WKT for a LINESTRING specified by the given Coordinate(s).
+func toLineStringWKT(pts ...Coordinate) string {
+	if len(pts) == 0 {
+		return "LINESTRING EMPTY"
+	}
+	return "LINESTRING " + toCoordinateListWKT(pts)
+}
+
+// Generates the WKT for a POLYGON specified by the given rings, with the shell first.
+func toPolygonWKT(rings [][]Coordinate) string {
+	if len(rings) == 0 {
+		return "POLYGON EMPTY"
+	}
+	ringTexts := make([]string, len(rings))
+	for i, ring := range rings {
+		ringTexts[i] = toCoordinateListWKT(ring)
+	}
+	return "POLYGON ( " + strings.Join(ringTexts, ", ") + " )"
+}
+
+// Generates the parenthesised list of ordinates of the Coordinate(s).
+func toCoordinateListWKT(pts []Coordinate) string {
+	ords := make([]string, len(pts))
+	for i, p := range pts {
+		ords[i] = fmt.Sprintf("%g %g", p.x, p.y)
+	}
+	return "( " + strings.Join(ords, ", ") + " )"
+}